@@ -1,7 +1,10 @@
 package main
 
 import (
+	"context"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/andreygrechin/kubectl-ips/pkg/cmd"
 	"github.com/spf13/pflag"
@@ -12,8 +15,13 @@ func main() {
 	flags := pflag.NewFlagSet("kubectl-ips", pflag.ExitOnError)
 	pflag.CommandLine = flags
 
+	// cancel in-flight API requests on interrupt so the command exits promptly
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+
 	root := cmd.NewCmdIPs(genericiooptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr})
-	if err := root.Execute(); err != nil {
+	err := root.ExecuteContext(ctx)
+	stop()
+	if err != nil {
 		os.Exit(1)
 	}
 }
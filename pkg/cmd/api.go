@@ -0,0 +1,296 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// PodIP is an IP address of a pod, as returned by ListPodIPs and printed by the json-ips output format.
+type PodIP struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"pod"`
+	Node      string `json:"node,omitempty"`
+	IP        string `json:"ip"`
+	Status    string `json:"status"`
+	// Cluster is the kubeconfig context the pod was listed from when several are queried.
+	Cluster string `json:"cluster,omitempty"`
+}
+
+func newPodIP(pod *corev1.Pod, ip string) PodIP {
+	return PodIP{
+		Namespace: pod.Namespace,
+		Name:      pod.Name,
+		Node:      pod.Spec.NodeName,
+		IP:        ip,
+		Status:    FormatPodStatus(pod),
+	}
+}
+
+// ListOptions selects the pods and IP addresses returned by ListPodIPs. The zero value lists every IP address
+// of every pod in all namespaces with a single request, ordered by namespace and name.
+type ListOptions struct {
+	// Namespace limits the listing to a single namespace. Empty means all namespaces.
+	Namespace string
+	// LabelSelector and FieldSelector filter the pods on the API server.
+	LabelSelector string
+	FieldSelector string
+	// ChunkSize lists the pods in pages of this size. Zero lists them all at once.
+	ChunkSize int64
+
+	// CIDR keeps only the IP addresses within the range.
+	CIDR *net.IPNet
+	// IPFamily keeps only "ipv4" or "ipv6" addresses. Empty or "all" keeps both.
+	IPFamily string
+	// IPSource reads the pod addresses from the "status", the default when empty, or from the Multus
+	// network-status "annotation", listing every interface of pods attached to several networks.
+	IPSource string
+	// Address keeps only this IP address, finding the pod owning it.
+	Address net.IP
+	// ExcludeNamespaces leaves out the pods in these namespaces.
+	ExcludeNamespaces []string
+	// Statuses keeps only the pods whose displayed status matches one of these values, ignoring case.
+	Statuses []string
+	// PodNamePattern, when set, keeps only the pods whose name matches it.
+	PodNamePattern *regexp.Regexp
+	// ExcludePodPattern, when set, leaves out the pods whose name matches it, including those kept by
+	// PodNamePattern.
+	ExcludePodPattern *regexp.Regexp
+	// ExcludeHostNetwork and HostNetworkOnly filter pods on whether they use the host network.
+	ExcludeHostNetwork bool
+	HostNetworkOnly    bool
+	// KeepDuplicates lists an IP address for every pod reporting it instead of only the first one.
+	KeepDuplicates bool
+	// SkipInvalidIPs leaves out the addresses that do not parse as IP addresses, written by a faulty CNI plugin.
+	SkipInvalidIPs bool
+	// IncludePending also lists pods without an IP address yet, with an empty IP, unless CIDR or Address is set.
+	IncludePending bool
+	// CreatedAfter and CreatedBefore, when set, keep only the pods created after and before these times.
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+
+	// SortBy orders the result by "name", "namespace", "ip", "age", "restarts" or "status".
+	// Empty orders by namespace and name.
+	SortBy string
+	// Reverse reverses the order of the result.
+	Reverse bool
+	// NoSort keeps the order the API server listed the pods in. SortBy must be empty.
+	NoSort bool
+	// IncludeInitRestarts counts init container restarts when sorting by "restarts".
+	IncludeInitRestarts bool
+}
+
+// ListPodIPs lists the IP addresses of the pods selected by the options.
+func ListPodIPs(ctx context.Context, clientset kubernetes.Interface, opts ListOptions) ([]PodIP, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	pods, err := listPods(ctx, clientset, opts.Namespace, opts.podListOptions(), opts.ChunkSize)
+	if err != nil {
+		return nil, err
+	}
+
+	items := listPodIPs(ipSources{pods: pods}, opts.ipListOptions())
+	podIPs := make([]PodIP, 0, len(items))
+	for _, item := range items {
+		podIPs = append(podIPs, newPodIP(item.pod, item.ip))
+	}
+
+	return podIPs, nil
+}
+
+func (opts ListOptions) validate() error {
+	if err := validateLabelSelector(opts.LabelSelector); err != nil {
+		return err
+	}
+
+	if err := validateIPFamily(opts.IPFamily); err != nil {
+		return err
+	}
+
+	if err := validateIPSource(opts.IPSource); err != nil {
+		return err
+	}
+
+	if opts.NoSort && opts.SortBy != sortByDefault {
+		return fmt.Errorf("%w: NoSort cannot be used with SortBy", ErrIncompatibleFlags)
+	}
+
+	return validateSortBy(opts.SortBy)
+}
+
+func (opts ListOptions) podListOptions() metav1.ListOptions {
+	return metav1.ListOptions{
+		LabelSelector: opts.LabelSelector,
+		FieldSelector: opts.FieldSelector,
+	}
+}
+
+func (opts ListOptions) ipListOptions() ipListOptions {
+	return ipListOptions{
+		filter: ipFilter{
+			cidr:               opts.CIDR,
+			family:             opts.IPFamily,
+			source:             opts.IPSource,
+			address:            opts.Address,
+			excludedNamespaces: opts.ExcludeNamespaces,
+			statuses:           opts.Statuses,
+			podNamePattern:     opts.PodNamePattern,
+			excludePodPattern:  opts.ExcludePodPattern,
+			excludeHostNetwork: opts.ExcludeHostNetwork,
+			hostNetworkOnly:    opts.HostNetworkOnly,
+			keepDuplicates:     opts.KeepDuplicates,
+			includePending:     opts.IncludePending,
+			skipInvalid:        opts.SkipInvalidIPs,
+			createdAfter:       opts.CreatedAfter,
+			createdBefore:      opts.CreatedBefore,
+		},
+		sortBy:              opts.SortBy,
+		reverse:             opts.Reverse,
+		noSort:              opts.NoSort,
+		includeInitRestarts: opts.IncludeInitRestarts,
+	}
+}
+
+// listPods lists pods in chunks of chunkSize, following continue tokens until the full set is accumulated.
+func listPods(
+	ctx context.Context, clientset kubernetes.Interface, namespace string, listOptions metav1.ListOptions, chunkSize int64,
+) (*corev1.PodList, error) {
+	listOptions.Limit = chunkSize
+	pods := &corev1.PodList{}
+
+	for {
+		page, err := clientset.CoreV1().Pods(namespace).List(ctx, listOptions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pods: %w", classifyAPIError(err))
+		}
+
+		// the first page carries the resource version of the consistent snapshot
+		if pods.ResourceVersion == "" {
+			pods.ResourceVersion = page.ResourceVersion
+		}
+		pods.Items = append(pods.Items, page.Items...)
+
+		if page.Continue == "" {
+			return pods, nil
+		}
+		listOptions.Continue = page.Continue
+	}
+}
+
+// classifiedError keeps the message of the error it wraps while also matching one of the Err* kinds, so that
+// callers can branch with errors.Is on the kind and still reach the underlying error, such as the
+// *apierrors.StatusError of a failed API request, with errors.As.
+type classifiedError struct {
+	kind error
+	err  error
+}
+
+func (e *classifiedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *classifiedError) Unwrap() []error {
+	return []error{e.kind, e.err}
+}
+
+// classifyAPIError tags the error of an API request rejected for its credentials or permissions with
+// ErrUnauthorized or ErrForbidden, returning other errors as is.
+func classifyAPIError(err error) error {
+	switch {
+	case apierrors.IsUnauthorized(err):
+		return &classifiedError{kind: ErrUnauthorized, err: err}
+	case apierrors.IsForbidden(err):
+		return &classifiedError{kind: ErrForbidden, err: err}
+	default:
+		return err
+	}
+}
+
+// forbiddenError explains which permission a forbidden API request lacked and how to get it, wrapping the error of
+// the API server.
+type forbiddenError struct {
+	verb      string
+	resource  string
+	namespace string
+	err       error
+}
+
+func (e *forbiddenError) Error() string {
+	if e.namespace == "" {
+		return fmt.Sprintf("cannot %[1]s %[2]s in all namespaces: forbidden by RBAC, ask a cluster administrator to "+
+			"bind you to a ClusterRole allowing it, e.g. created with: "+
+			"kubectl create clusterrole %[2]s-reader --verb=%[1]s --resource=%[2]s", e.verb, e.resource)
+	}
+
+	return fmt.Sprintf("cannot %[1]s %[2]s in namespace %[3]q: forbidden by RBAC, ask a cluster administrator to "+
+		"bind you to a Role allowing it, e.g. created with: "+
+		"kubectl create role %[2]s-reader --verb=%[1]s --resource=%[2]s -n %[3]s", e.verb, e.resource, e.namespace)
+}
+
+func (e *forbiddenError) Unwrap() error {
+	return e.err
+}
+
+// validateLabelSelector rejects malformed label selectors before they reach the API server.
+func validateLabelSelector(selector string) error {
+	if selector == "" {
+		return nil
+	}
+
+	if _, err := labels.Parse(selector); err != nil {
+		return fmt.Errorf("invalid label selector %q: %w", selector, err)
+	}
+
+	return nil
+}
+
+// joinSelectors combines the label selectors of repeated --selector flags into one matching all of them, as the
+// requirements of a selector are ANDed. Empty selectors are left out.
+func joinSelectors(selectors []string) string {
+	var requirements []string
+	for _, selector := range selectors {
+		if selector = strings.TrimSpace(selector); selector != "" {
+			requirements = append(requirements, selector)
+		}
+	}
+
+	return strings.Join(requirements, ",")
+}
+
+func validateIPFamily(family string) error {
+	switch family {
+	case ipFamilyAll, ipFamilyIPv4, ipFamilyIPv6, "":
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedIPFamily, family)
+	}
+}
+
+func validateIPSource(source string) error {
+	switch source {
+	case ipSourceStatus, ipSourceAnnotation, "":
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedIPSource, source)
+	}
+}
+
+func validateSortBy(sortBy string) error {
+	switch sortBy {
+	case sortByDefault, sortByName, sortByNamespace, sortByIP, sortByAge, sortByRestarts, sortByStatus:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedSortKey, sortBy)
+	}
+}
@@ -0,0 +1,194 @@
+package cmd_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/andreygrechin/kubectl-ips/pkg/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestListPodIPs(t *testing.T) {
+	web := newTestPod("web", "10.244.1.5", "10.244.1.5", "fd00::5")
+	web.Spec.NodeName = "worker-1"
+	web.Status.Phase = corev1.PodRunning
+	db := newTestPod("db", "10.244.2.7")
+	db.Namespace = "data"
+	db.Status.Phase = corev1.PodPending
+	pending := newTestPod("pending", "")
+
+	clientset := fake.NewClientset(&web, &db, &pending)
+
+	tests := map[string]struct {
+		opts     cmd.ListOptions
+		expected []cmd.PodIP
+	}{
+		"all namespaces": {
+			opts: cmd.ListOptions{},
+			expected: []cmd.PodIP{
+				{Namespace: "data", Name: "db", IP: "10.244.2.7", Status: "Pending"},
+				{Namespace: "default", Name: "web", Node: "worker-1", IP: "10.244.1.5", Status: "Running"},
+				{Namespace: "default", Name: "web", Node: "worker-1", IP: "fd00::5", Status: "Running"},
+			},
+		},
+		"single namespace and family": {
+			opts: cmd.ListOptions{Namespace: "default", IPFamily: "ipv6"},
+			expected: []cmd.PodIP{
+				{Namespace: "default", Name: "web", Node: "worker-1", IP: "fd00::5", Status: "Running"},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			podIPs, err := cmd.ListPodIPs(context.Background(), clientset, tc.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, podIPs)
+		})
+	}
+}
+
+func TestListPodIPs_sortByRestartsWithInit(t *testing.T) {
+	initLooping := newTestPod("init-looping", "10.244.1.5")
+	initLooping.Status.InitContainerStatuses = []corev1.ContainerStatus{{RestartCount: 5}}
+	flaky := newTestPod("flaky", "10.244.1.6")
+	flaky.Status.ContainerStatuses = []corev1.ContainerStatus{{RestartCount: 2}}
+
+	clientset := fake.NewClientset(&initLooping, &flaky)
+
+	tests := map[string]struct {
+		includeInit bool
+		expected    []string
+	}{
+		"main containers only": {
+			includeInit: false,
+			expected:    []string{"init-looping", "flaky"},
+		},
+		"with init containers": {
+			includeInit: true,
+			expected:    []string{"flaky", "init-looping"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			podIPs, err := cmd.ListPodIPs(context.Background(), clientset, cmd.ListOptions{
+				SortBy:              "restarts",
+				IncludeInitRestarts: tc.includeInit,
+			})
+			require.NoError(t, err)
+
+			names := make([]string, 0, len(podIPs))
+			for _, podIP := range podIPs {
+				names = append(names, podIP.Name)
+			}
+			assert.Equal(t, tc.expected, names)
+		})
+	}
+}
+
+func TestListPodIPs_noSort(t *testing.T) {
+	// the fake tracker lists pods in no particular order, so serve them in a fixed one
+	pods := &corev1.PodList{Items: []corev1.Pod{
+		newTestPod("web", "10.244.1.5"),
+		newTestPod("api", "10.244.3.2"),
+		newTestPod("db", "10.244.2.7"),
+	}}
+	clientset := fake.NewClientset()
+	clientset.PrependReactor("list", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, pods, nil
+	})
+
+	tests := map[string]struct {
+		opts     cmd.ListOptions
+		expected []string
+	}{
+		"sorted":           {opts: cmd.ListOptions{}, expected: []string{"api", "db", "web"}},
+		"listing order":    {opts: cmd.ListOptions{NoSort: true}, expected: []string{"web", "api", "db"}},
+		"reversed listing": {opts: cmd.ListOptions{NoSort: true, Reverse: true}, expected: []string{"db", "api", "web"}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			podIPs, err := cmd.ListPodIPs(context.Background(), clientset, tc.opts)
+			require.NoError(t, err)
+			names := make([]string, 0, len(podIPs))
+			for _, podIP := range podIPs {
+				names = append(names, podIP.Name)
+			}
+			assert.Equal(t, tc.expected, names)
+		})
+	}
+}
+
+func TestListPodIPs_apiErrors(t *testing.T) {
+	tests := map[string]struct {
+		err         error
+		expectedErr error
+	}{
+		"unauthorized": {
+			err:         apierrors.NewUnauthorized("token expired"),
+			expectedErr: cmd.ErrUnauthorized,
+		},
+		"forbidden": {
+			err: apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "",
+				errors.New("user cannot list pods")),
+			expectedErr: cmd.ErrForbidden,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			clientset := fake.NewClientset()
+			clientset.PrependReactor("list", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, tc.err
+			})
+
+			_, err := cmd.ListPodIPs(context.Background(), clientset, cmd.ListOptions{})
+			require.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, "failed to list pods: "+tc.err.Error(), err.Error())
+
+			// the status error of the API server stays reachable
+			var statusErr *apierrors.StatusError
+			require.ErrorAs(t, err, &statusErr)
+			assert.Equal(t, tc.err, statusErr)
+		})
+	}
+
+	// other API errors are not classified
+	clientset := fake.NewClientset()
+	clientset.PrependReactor("list", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewInternalError(errors.New("etcd unavailable"))
+	})
+	_, err := cmd.ListPodIPs(context.Background(), clientset, cmd.ListOptions{})
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, cmd.ErrForbidden)
+	assert.NotErrorIs(t, err, cmd.ErrUnauthorized)
+}
+
+func TestListPodIPs_invalidOptions(t *testing.T) {
+	clientset := fake.NewClientset()
+
+	_, err := cmd.ListPodIPs(context.Background(), clientset, cmd.ListOptions{SortBy: "size"})
+	require.ErrorIs(t, err, cmd.ErrUnsupportedSortKey)
+
+	_, err = cmd.ListPodIPs(context.Background(), clientset, cmd.ListOptions{IPFamily: "ipv5"})
+	require.ErrorIs(t, err, cmd.ErrUnsupportedIPFamily)
+
+	_, err = cmd.ListPodIPs(context.Background(), clientset, cmd.ListOptions{IPSource: "spec"})
+	require.ErrorIs(t, err, cmd.ErrUnsupportedIPSource)
+
+	_, err = cmd.ListPodIPs(context.Background(), clientset, cmd.ListOptions{LabelSelector: "app in (web"})
+	require.ErrorContains(t, err, "invalid label selector")
+
+	_, err = cmd.ListPodIPs(context.Background(), clientset, cmd.ListOptions{NoSort: true, SortBy: "ip"})
+	require.ErrorIs(t, err, cmd.ErrIncompatibleFlags)
+}
@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// outputFormatCompletions lists the values of --output in the order of its help text. Formats taking an
+// argument end with "=" so that the shell lets the argument follow.
+var outputFormatCompletions = []string{
+	tableFormat, wideFormat, wideIPv6Format, jsonFormat, yamlFormat, nameFormat, csvFormat, tsvFormat,
+	customColumnsFormat + "=", customColumnsFileFormat + "=", jsonPathFormat + "=", goTemplateFormat + "=",
+	goTemplateFileFormat + "=", hostsFormat, prometheusFormat, jsonIPsFormat, jsonlFormat,
+}
+
+// completeOutputFormats suggests the --output values starting with toComplete.
+func completeOutputFormats(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var formats []string
+	for _, format := range outputFormatCompletions {
+		if strings.HasPrefix(format, toComplete) {
+			formats = append(formats, format)
+		}
+	}
+
+	directive := cobra.ShellCompDirectiveNoFileComp
+	if len(formats) == 1 && strings.HasSuffix(formats[0], "=") {
+		directive |= cobra.ShellCompDirectiveNoSpace
+	}
+
+	return formats, directive
+}
+
+// completeNamespaces suggests the names of the namespaces in the cluster selected by the kubeconfig flags
+// parsed so far, such as --context and --kubeconfig. Errors leave the suggestions empty rather than
+// interrupting the shell.
+func (o *IPsOptions) completeNamespaces(
+	cmd *cobra.Command, _ []string, toComplete string,
+) ([]string, cobra.ShellCompDirective) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	names, err := o.namespaceNames(ctx, toComplete)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// namespaceNames lists the names of the namespaces starting with prefix.
+func (o *IPsOptions) namespaceNames(ctx context.Context, prefix string) ([]string, error) {
+	clientset, err := o.buildClientset()
+	if err != nil {
+		return nil, err
+	}
+
+	var namespaces *corev1.NamespaceList
+	err = o.withTimeout(ctx, func(ctx context.Context) error {
+		var err error
+		namespaces, err = clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to list namespaces: %w", classifyAPIError(err))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, namespace := range namespaces.Items {
+		if strings.HasPrefix(namespace.Name, prefix) {
+			names = append(names, namespace.Name)
+		}
+	}
+
+	return names, nil
+}
+
+// completeLabelSelector suggests label keys, or the values of the key being typed, taken from the pods in the
+// namespace the command would list. Every term before the last comma is kept as typed.
+func (o *IPsOptions) completeLabelSelector(
+	cmd *cobra.Command, args []string, toComplete string,
+) ([]string, cobra.ShellCompDirective) {
+	if err := o.Complete(cmd, args); err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	suggestions, err := o.labelSelectorSuggestions(ctx, toComplete)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	// a selector is rarely complete after a single term, so leave room for a comma
+	return suggestions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
+
+// labelSelectorSuggestions completes the last term of the partial selector: its key when no operator was
+// typed yet, otherwise the value after a '=', '==' or '!=' operator. Set-based terms are not completed.
+func (o *IPsOptions) labelSelectorSuggestions(ctx context.Context, toComplete string) ([]string, error) {
+	if strings.Count(toComplete, "(") > strings.Count(toComplete, ")") {
+		return nil, nil
+	}
+
+	prefix, term := "", toComplete
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		prefix, term = toComplete[:i+1], toComplete[i+1:]
+	}
+	if strings.ContainsRune(term, ' ') {
+		return nil, nil
+	}
+
+	labelValues, err := o.podLabelValues(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var suggestions []string
+	if negated, ok := strings.CutPrefix(term, "!"); ok {
+		prefix, term = prefix+"!", negated
+	}
+	i := strings.IndexAny(term, "!=")
+	if i < 0 {
+		for key := range labelValues {
+			if strings.HasPrefix(key, term) {
+				suggestions = append(suggestions, prefix+key)
+			}
+		}
+	} else {
+		key, rest := term[:i], term[i:]
+		value := strings.TrimLeft(rest, "!=")
+		operator := rest[:len(rest)-len(value)]
+		for candidate := range labelValues[key] {
+			if strings.HasPrefix(candidate, value) {
+				suggestions = append(suggestions, prefix+key+operator+candidate)
+			}
+		}
+	}
+	slices.Sort(suggestions)
+
+	return suggestions, nil
+}
+
+// podLabelValues collects the distinct values of every label set on the pods in the namespace.
+func (o *IPsOptions) podLabelValues(ctx context.Context) (map[string]map[string]bool, error) {
+	clientset, err := o.buildClientset()
+	if err != nil {
+		return nil, err
+	}
+
+	var pods *corev1.PodList
+	err = o.withTimeout(ctx, func(ctx context.Context) error {
+		var err error
+		pods, err = listPods(ctx, clientset, o.namespace, metav1.ListOptions{}, o.chunkSize)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	labelValues := make(map[string]map[string]bool)
+	for _, pod := range pods.Items {
+		for key, value := range pod.Labels {
+			if labelValues[key] == nil {
+				labelValues[key] = make(map[string]bool)
+			}
+			labelValues[key][value] = true
+		}
+	}
+
+	return labelValues, nil
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// extractContextIPs collects the matching IP addresses from every context passed to --contexts, tagging each
+// with its context. A failing context is reported on stderr without dropping the results of the others, and
+// only fails the command when no context could be listed.
+func (o *IPsOptions) extractContextIPs(ctx context.Context) ([]podIPWithPod, error) {
+	var podIPs []podIPWithPod
+	var errs []error
+
+	for _, name := range o.contexts {
+		items, err := o.extractContextIPsFrom(ctx, name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("context %q: %w", name, err))
+
+			continue
+		}
+		for i := range items {
+			items[i].cluster = name
+		}
+		podIPs = append(podIPs, items...)
+	}
+
+	if len(errs) == len(o.contexts) {
+		return nil, errors.Join(errs...)
+	}
+	for _, err := range errs {
+		_, _ = fmt.Fprintf(o.ErrOut, "error: %v\n", err)
+	}
+
+	return podIPs, nil
+}
+
+func (o *IPsOptions) extractContextIPsFrom(ctx context.Context, name string) ([]podIPWithPod, error) {
+	clientset, namespace, err := o.contextClient(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return o.extractClusterIPs(ctx, clientset, namespace)
+}
+
+// contextClient builds a client for the kubeconfig context, returning the namespace to list in it: the
+// requested one, or the context's own default when none was requested.
+func (o *IPsOptions) contextClient(name string) (kubernetes.Interface, string, error) {
+	if o.contextClientsets != nil {
+		clientset, ok := o.contextClientsets[name]
+		if !ok {
+			return nil, "", fmt.Errorf("%w: %q", ErrContextNotFound, name)
+		}
+
+		return clientset, o.namespace, nil
+	}
+
+	clientConfig, err := o.contextClientConfig(name)
+	if err != nil {
+		return nil, "", err
+	}
+	config, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get REST config: %w", err)
+	}
+
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	namespace := o.namespace
+	if o.namespaceFromContext {
+		namespace, _, err = clientConfig.Namespace()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get namespace: %w", err)
+		}
+	}
+
+	return clientset, namespace, nil
+}
+
+// contextClientConfig loads the kubeconfig context, impersonating the user passed to --as, --as-group and --as-uid
+// like the client of the current context does.
+func (o *IPsOptions) contextClientConfig(name string) (clientcmd.ClientConfig, error) {
+	loader := o.configFlags.ToRawKubeConfigLoader()
+	rawConfig, err := loader.RawConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	if _, ok := rawConfig.Contexts[name]; !ok {
+		return nil, fmt.Errorf("%w: %q", ErrContextNotFound, name)
+	}
+
+	overrides := &clientcmd.ConfigOverrides{}
+	if o.configFlags.Impersonate != nil {
+		overrides.AuthInfo.Impersonate = *o.configFlags.Impersonate
+	}
+	if o.configFlags.ImpersonateUID != nil {
+		overrides.AuthInfo.ImpersonateUID = *o.configFlags.ImpersonateUID
+	}
+	if o.configFlags.ImpersonateGroup != nil {
+		overrides.AuthInfo.ImpersonateGroups = *o.configFlags.ImpersonateGroup
+	}
+
+	return clientcmd.NewNonInteractiveClientConfig(rawConfig, name, overrides, loader.ConfigAccess()), nil
+}
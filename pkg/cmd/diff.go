@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"slices"
+	"strings"
+
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+const (
+	diffAdded   = "+"
+	diffRemoved = "-"
+	diffChanged = "~"
+)
+
+// podIPChange is a pod whose IP addresses differ between the --diff snapshot and the current state.
+type podIPChange struct {
+	marker    string
+	cluster   string
+	namespace string
+	name      string
+	ips       []string
+	oldIPs    []string
+}
+
+// printDiff prints the pods whose IP addresses were added, removed or changed since the --diff snapshot, marking
+// each row with +, - or ~. Services and pods without an IP are left out, as in the json-ips snapshot.
+func (o *IPsOptions) printDiff(podIPs []podIPWithPod) error {
+	snapshot, err := readSnapshot(o.diff)
+	if err != nil {
+		return err
+	}
+
+	current := make([]PodIP, 0, len(podIPs))
+	for _, item := range podIPs {
+		if item.pod == nil || item.service != nil || item.endpointSlice != nil || item.ip == "" {
+			continue
+		}
+		podIP := newPodIP(item.pod, item.ip)
+		podIP.Cluster = item.cluster
+		current = append(current, podIP)
+	}
+
+	changes := diffPodIPs(snapshot, current)
+	if len(changes) == 0 {
+		_, _ = fmt.Fprintf(o.ErrOut, "No pod IP changes since %s\n", o.diff)
+
+		return nil
+	}
+
+	showCluster := slices.ContainsFunc(changes, func(change podIPChange) bool { return change.cluster != "" })
+	formatIPs := func(ips []string) string {
+		if len(ips) == 0 {
+			return noneValue
+		}
+		if o.expandIPv6 {
+			ips = slices.Clone(ips)
+			for i, ip := range ips {
+				ips[i] = expandIPv6(ip)
+			}
+		}
+
+		return strings.Join(ips, ",")
+	}
+
+	// the marker leads every row like in a unified diff, with the header indented to match
+	writer := printers.GetNewTabWriter(o.Out)
+	if !o.noHeaders {
+		header := []string{"NAMESPACE", "NAME", "IP", "PREVIOUS-IP"}
+		if showCluster {
+			header = slices.Insert(header, 0, "CLUSTER")
+		}
+		_, _ = fmt.Fprintln(writer, "  "+strings.Join(header, "\t"))
+	}
+	for _, change := range changes {
+		row := []string{change.namespace, change.name, formatIPs(change.ips), formatIPs(change.oldIPs)}
+		if showCluster {
+			row = slices.Insert(row, 0, cmp.Or(change.cluster, noneValue))
+		}
+		_, _ = fmt.Fprintln(writer, change.marker+" "+strings.Join(row, "\t"))
+	}
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("failed to flush output: %w", err)
+	}
+
+	return nil
+}
+
+// readSnapshot reads the pod IPs saved with -o json-ips or -o jsonl.
+func readSnapshot(filename string) ([]PodIP, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snapshot: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var podIPs []PodIP
+	decoder := json.NewDecoder(file)
+	for {
+		// a json-ips array holds every pod IP, a jsonl stream has an object per line
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				return podIPs, nil
+			}
+
+			return nil, fmt.Errorf("failed to read snapshot %s: %w", filename, err)
+		}
+
+		var items []PodIP
+		if strings.HasPrefix(string(raw), "[") {
+			err = json.Unmarshal(raw, &items)
+		} else {
+			items = make([]PodIP, 1)
+			err = json.Unmarshal(raw, &items[0])
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read snapshot %s: %w", filename, err)
+		}
+		podIPs = append(podIPs, items...)
+	}
+}
+
+// diffPodIPs compares the IP addresses of every pod, identified by its cluster, namespace and name, ordering the
+// changes by pod. Addresses are compared in their canonical form, so that a snapshot written with --ipv6-expand
+// matches the current state.
+func diffPodIPs(old, current []PodIP) []podIPChange {
+	type podKey struct {
+		cluster, namespace, name string
+	}
+	group := func(podIPs []PodIP) map[podKey][]string {
+		ips := make(map[podKey][]string)
+		for _, podIP := range podIPs {
+			key := podKey{cluster: podIP.Cluster, namespace: podIP.Namespace, name: podIP.Name}
+			ip := podIP.IP
+			if addr, err := netip.ParseAddr(ip); err == nil {
+				ip = addr.String()
+			}
+			if !slices.Contains(ips[key], ip) {
+				ips[key] = append(ips[key], ip)
+			}
+		}
+
+		return ips
+	}
+	oldIPs, currentIPs := group(old), group(current)
+
+	var changes []podIPChange
+	for key, ips := range currentIPs {
+		change := podIPChange{cluster: key.cluster, namespace: key.namespace, name: key.name, ips: ips}
+		previous, found := oldIPs[key]
+		switch {
+		case !found:
+			change.marker = diffAdded
+		case !sameIPs(ips, previous):
+			change.marker, change.oldIPs = diffChanged, previous
+		default:
+			continue
+		}
+		changes = append(changes, change)
+	}
+	for key, ips := range oldIPs {
+		if _, found := currentIPs[key]; !found {
+			changes = append(changes, podIPChange{
+				marker: diffRemoved, cluster: key.cluster, namespace: key.namespace, name: key.name, oldIPs: ips,
+			})
+		}
+	}
+
+	slices.SortFunc(changes, func(a, b podIPChange) int {
+		return cmp.Or(
+			cmp.Compare(a.cluster, b.cluster),
+			cmp.Compare(a.namespace, b.namespace),
+			cmp.Compare(a.name, b.name),
+		)
+	})
+
+	return changes
+}
+
+// sameIPs reports whether both pods have the same addresses, in any order.
+func sameIPs(a, b []string) bool {
+	return len(a) == len(b) && !slices.ContainsFunc(a, func(ip string) bool { return !slices.Contains(b, ip) })
+}
+
+// validateDiff rejects the flags that print something else than the table of changes of --diff.
+func (o *IPsOptions) validateDiff() error {
+	if o.diff == "" {
+		return nil
+	}
+
+	switch {
+	case o.outputFormat != tableFormat && o.outputFormat != wideFormat && o.outputFormat != "":
+		return fmt.Errorf("%w: --diff can only be used with table output", ErrIncompatibleFlags)
+	case o.count:
+		return fmt.Errorf("%w: --diff cannot be used with --count", ErrIncompatibleFlags)
+	case o.summary:
+		return fmt.Errorf("%w: --diff cannot be used with --summary", ErrIncompatibleFlags)
+	case o.watch:
+		return fmt.Errorf("%w: --diff cannot be used with --watch", ErrIncompatibleFlags)
+	case o.showIPsOnly:
+		return fmt.Errorf("%w: --diff cannot be used with --show-ips-only", ErrIncompatibleFlags)
+	case o.rawIPs:
+		return fmt.Errorf("%w: --diff cannot be used with --raw-ips", ErrIncompatibleFlags)
+	case o.dualStack:
+		return fmt.Errorf("%w: --diff cannot be used with --wide-with-ipv6", ErrIncompatibleFlags)
+	case o.nodes:
+		return fmt.Errorf("%w: --diff cannot be used with --nodes", ErrIncompatibleFlags)
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+const day = 24 * time.Hour
+
+// durationValue is a duration flag accepting a day suffix on top of the units of time.ParseDuration,
+// as pod ages are usually counted in days.
+type durationValue time.Duration
+
+func newDurationValue(p *time.Duration) *durationValue {
+	return (*durationValue)(p)
+}
+
+func (d *durationValue) Set(value string) error {
+	parsed, err := parseDuration(value)
+	if err != nil {
+		return err
+	}
+	*d = durationValue(parsed)
+
+	return nil
+}
+
+func (d *durationValue) String() string {
+	return time.Duration(*d).String()
+}
+
+func (d *durationValue) Type() string {
+	return "duration"
+}
+
+// parseDuration parses a duration like time.ParseDuration, also accepting a leading number of days such as 30d
+// or 1d12h.
+func parseDuration(value string) (time.Duration, error) {
+	days, rest, found := strings.Cut(value, "d")
+	if !found {
+		return time.ParseDuration(value)
+	}
+
+	sign := time.Duration(1)
+	if unsigned, ok := strings.CutPrefix(days, "-"); ok {
+		days, sign = unsigned, -1
+	}
+	count, err := strconv.ParseUint(days, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+
+	parsed := time.Duration(count) * day
+	if rest != "" {
+		remainder, err := time.ParseDuration(rest)
+		if err != nil || remainder < 0 {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		parsed += remainder
+	}
+
+	return sign * parsed, nil
+}
+
+// formatDuration returns the duration in the short form kubectl uses for ages, e.g. 30d or 10m.
+func formatDuration(d time.Duration) string {
+	return duration.HumanDuration(d)
+}
@@ -0,0 +1,505 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// CreatePrinter exposes printer construction to external tests.
+func CreatePrinter(outputFormat string, noHeaders, showNamespace bool) (ResourcePrinter, error) {
+	return createPrinter(outputFormat, printOptions{noHeaders: noHeaders, showNamespace: showNamespace})
+}
+
+// CreateColorPrinter exposes colored table printer construction to external tests.
+func CreateColorPrinter(outputFormat string, noHeaders bool) (ResourcePrinter, error) {
+	return createPrinter(outputFormat, printOptions{noHeaders: noHeaders, color: true})
+}
+
+// CreateAlignedPrinter exposes construction of the aligned table printer to external tests.
+func CreateAlignedPrinter(outputFormat string, noHeaders, color bool) (ResourcePrinter, error) {
+	return createPrinter(outputFormat, printOptions{noHeaders: noHeaders, color: color, alignedColumns: true})
+}
+
+// CreateTruncatingPrinter exposes construction of printers truncating long table cells to external tests.
+func CreateTruncatingPrinter(outputFormat string, maxColumnWidth int) (ResourcePrinter, error) {
+	return createPrinter(outputFormat, printOptions{maxColumnWidth: maxColumnWidth})
+}
+
+// CreateStrictTemplatePrinter exposes construction of template printers failing on missing keys to external tests.
+func CreateStrictTemplatePrinter(outputFormat string) (ResourcePrinter, error) {
+	return createPrinter(outputFormat, printOptions{strictTemplates: true})
+}
+
+// CreateHostsPrinter exposes hosts printer construction with the given hostname template to external tests.
+func CreateHostsPrinter(hostnameTemplate string) (ResourcePrinter, error) {
+	return createPrinter(hostsFormat, printOptions{hostnameTemplate: hostnameTemplate})
+}
+
+// ExtractPodIPs exposes pod IP extraction with the filters of the given options to external tests.
+func ExtractPodIPs(pods *corev1.PodList, opts ListOptions) []string {
+	items := extractPodIPsWithPods(pods, opts.ipListOptions().filter)
+	ips := make([]string, 0, len(items))
+	for _, item := range items {
+		ips = append(ips, item.ip)
+	}
+
+	return ips
+}
+
+// SummarizeIPs exposes the --count summary of the given pods and services to external tests.
+func SummarizeIPs(pods *corev1.PodList, services *corev1.ServiceList) string {
+	return summarizeIPs(listPodIPs(ipSources{pods: pods, services: services}, ipListOptions{})).String()
+}
+
+// SortPodIPs exposes pod IP extraction and sorting by the given key to external tests.
+func SortPodIPs(pods *corev1.PodList, sortBy string, reverse bool) []string {
+	items := listPodIPs(ipSources{pods: pods}, ipListOptions{sortBy: sortBy, reverse: reverse})
+	ips := make([]string, 0, len(items))
+	for _, item := range items {
+		ips = append(ips, item.ip)
+	}
+
+	return ips
+}
+
+// SortPodUIDs exposes sorting of every pod IP, duplicates included, to external tests, returning the UIDs
+// of the pods in their sorted order.
+func SortPodUIDs(pods *corev1.PodList) []string {
+	items := listPodIPs(ipSources{pods: pods}, ipListOptions{filter: ipFilter{keepDuplicates: true}})
+	uids := make([]string, 0, len(items))
+	for _, item := range items {
+		uids = append(uids, string(item.pod.UID))
+	}
+
+	return uids
+}
+
+// ExtractServiceIPs exposes service IP extraction to external tests.
+func ExtractServiceIPs(services *corev1.ServiceList) []string {
+	items := extractServiceIPs(services, ipFilter{})
+	ips := make([]string, 0, len(items))
+	for _, item := range items {
+		ips = append(ips, item.ip)
+	}
+
+	return ips
+}
+
+// GenerateNodeTable exposes node table generation with the given IP family filter to external tests.
+func GenerateNodeTable(nodes *corev1.NodeList, family string) *metav1.Table {
+	return generateNodeTable(nodes, ipFilter{family: family})
+}
+
+// ParseDuration exposes parsing of duration flags accepting a day suffix to external tests.
+func ParseDuration(value string) (time.Duration, error) {
+	return parseDuration(value)
+}
+
+// CompleteNamespaces exposes the --namespace flag completion to external tests.
+func (o *IPsOptions) CompleteNamespaces(toComplete string) []string {
+	names, _ := o.completeNamespaces(&cobra.Command{}, nil, toComplete)
+
+	return names
+}
+
+// LabelSelectorSuggestions exposes the --selector flag completion to external tests.
+func (o *IPsOptions) LabelSelectorSuggestions(toComplete string) ([]string, error) {
+	return o.labelSelectorSuggestions(context.Background(), toComplete)
+}
+
+// AddConfigFlags registers the kubeconfig flags of the options on the flag set for external tests.
+func (o *IPsOptions) AddConfigFlags(flags *pflag.FlagSet) {
+	o.configFlags.AddFlags(flags)
+}
+
+// RESTConfig exposes the REST config of the current context to external tests.
+func (o *IPsOptions) RESTConfig() (*rest.Config, error) {
+	return o.configFlags.ToRESTConfig()
+}
+
+// BuildClientset exposes the client of the current context to external tests.
+func (o *IPsOptions) BuildClientset() (kubernetes.Interface, error) {
+	return o.buildClientset()
+}
+
+// ContextRESTConfig exposes the REST config of a context passed to --contexts to external tests.
+func (o *IPsOptions) ContextRESTConfig(name string) (*rest.Config, error) {
+	clientConfig, err := o.contextClientConfig(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return clientConfig.ClientConfig()
+}
+
+// SetRetryDelay shortens the wait before retrying a transient error in tests.
+func (o *IPsOptions) SetRetryDelay(delay time.Duration) {
+	o.retryDelay = delay
+}
+
+// DropNotRunningPods exposes the --running-only pod filtering to external tests.
+func (o *IPsOptions) DropNotRunningPods(pods *corev1.PodList) {
+	o.dropNotRunningPods(pods)
+}
+
+// SetCIDR sets the CIDR range used to filter pod IPs for testing purposes.
+func (o *IPsOptions) SetCIDR(cidr string) {
+	o.cidr = cidr
+}
+
+// SetIPFamily sets the IP family used to filter pod IPs for testing purposes.
+func (o *IPsOptions) SetIPFamily(family string) {
+	o.ipFamily = family
+}
+
+// SetLookup sets the IP address to look up for testing purposes.
+func (o *IPsOptions) SetLookup(lookup string) {
+	o.lookup = lookup
+}
+
+// SetTimeout sets the API request timeout for testing purposes.
+func (o *IPsOptions) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// SetRetries sets the number of retries after transient errors for testing purposes.
+func (o *IPsOptions) SetRetries(retries int) {
+	o.retries = retries
+}
+
+// SetWait polls until count pods have an IP, every interval for at most timeout, for testing purposes.
+func (o *IPsOptions) SetWait(count int, timeout, interval time.Duration) {
+	o.wait = true
+	o.waitCount = count
+	o.waitTimeout = timeout
+	o.waitInterval = interval
+}
+
+// SetChunkSize sets the page size used when listing pods for testing purposes.
+func (o *IPsOptions) SetChunkSize(chunkSize int64) {
+	o.chunkSize = chunkSize
+}
+
+// SetContainers expands pods into a row per container for testing purposes.
+func (o *IPsOptions) SetContainers(containers bool) {
+	o.containers = containers
+}
+
+// SetIncludeInitRestarts counts init container restarts for testing purposes.
+func (o *IPsOptions) SetIncludeInitRestarts(includeInitRestarts bool) {
+	o.includeInitRestarts = includeInitRestarts
+}
+
+// SetLabelColumns sets the labels shown as columns for testing purposes.
+func (o *IPsOptions) SetLabelColumns(keys []string) {
+	o.labelColumns = keys
+}
+
+// SetAnnotationColumns sets the annotations shown as columns for testing purposes.
+func (o *IPsOptions) SetAnnotationColumns(keys []string) {
+	o.annotationColumns = keys
+}
+
+// SetIPSource sets where pod IP addresses are read from for testing purposes.
+func (o *IPsOptions) SetIPSource(source string) {
+	o.ipSource = source
+}
+
+// SetAllInterfaces sets whether the addresses of every pod interface are listed for testing purposes.
+func (o *IPsOptions) SetAllInterfaces(allInterfaces bool) {
+	o.allInterfaces = allInterfaces
+}
+
+// SetCompact prints JSON output on a single line for testing purposes.
+func (o *IPsOptions) SetCompact(compact bool) {
+	o.compact = compact
+}
+
+// SetShowLabels adds the LABELS column for testing purposes.
+func (o *IPsOptions) SetShowLabels(showLabels bool) {
+	o.showLabels = showLabels
+}
+
+// SetNodeLabelColumns sets the node labels shown as columns for testing purposes.
+func (o *IPsOptions) SetNodeLabelColumns(keys []string) {
+	o.nodeLabelColumns = keys
+}
+
+// SetShowIPsOnly prints bare IP addresses for testing purposes.
+func (o *IPsOptions) SetShowIPsOnly(showIPsOnly bool) {
+	o.showIPsOnly = showIPsOnly
+}
+
+// SetIPsDelimiter sets the separator between --show-ips-only addresses, as given on the command line, for testing
+// purposes.
+func (o *IPsOptions) SetIPsDelimiter(delimiter string) {
+	o.ipsDelimiter = delimiter
+}
+
+// SetRawIPs sets whether only the IPs are printed on a single line for testing purposes.
+func (o *IPsOptions) SetRawIPs(rawIPs bool) {
+	o.rawIPs = rawIPs
+}
+
+// SetMaxPods caps the number of printed rows for testing purposes.
+func (o *IPsOptions) SetMaxPods(maxPods int) {
+	o.maxPods = maxPods
+}
+
+// SetSortBy sets the sort key for testing purposes.
+func (o *IPsOptions) SetSortBy(sortBy string) {
+	o.sortBy = sortBy
+}
+
+// SetNoSort keeps the listing order of the API server for testing purposes.
+func (o *IPsOptions) SetNoSort(noSort bool) {
+	o.noSort = noSort
+}
+
+// SetReverse reverses the sort order for testing purposes.
+func (o *IPsOptions) SetReverse(reverse bool) {
+	o.reverse = reverse
+}
+
+// SetFieldSelector sets the field selector for testing purposes.
+func (o *IPsOptions) SetFieldSelector(selector string) {
+	o.fieldSelector = selector
+}
+
+// SetSelectorFromPod selects the pods labeled like the named one for testing purposes.
+func (o *IPsOptions) SetSelectorFromPod(name string) {
+	o.selectorFromPod = name
+}
+
+// SetPodNames limits the listing to the named pods for testing purposes.
+func (o *IPsOptions) SetPodNames(names []string) {
+	o.podNames = names
+}
+
+// SetDiff sets the snapshot the pod IPs are compared with for testing purposes.
+func (o *IPsOptions) SetDiff(diff string) {
+	o.diff = diff
+}
+
+// SetServerPrint prints the pod table built by the API server for testing purposes.
+func (o *IPsOptions) SetServerPrint(serverPrint bool) {
+	o.serverPrint = serverPrint
+}
+
+// SetFilename reads the pods from the file, or stdin for "-", instead of the cluster for testing purposes.
+func (o *IPsOptions) SetFilename(filename string) {
+	o.filename = filename
+}
+
+// SetWatch enables watch mode for testing purposes.
+func (o *IPsOptions) SetWatch(watch bool) {
+	o.watch = watch
+}
+
+// SetIncludeServices enables listing service IPs for testing purposes.
+func (o *IPsOptions) SetIncludeServices(includeServices bool) {
+	o.includeServices = includeServices
+}
+
+// SetRunningOnly limits the output to running pods for testing purposes.
+func (o *IPsOptions) SetRunningOnly(runningOnly bool) {
+	o.runningOnly = runningOnly
+}
+
+// SetHostNetworkFilters sets the host network filters for testing purposes.
+func (o *IPsOptions) SetHostNetworkFilters(excludeHostNetwork, hostNetworkOnly bool) {
+	o.excludeHostNetwork = excludeHostNetwork
+	o.hostNetworkOnly = hostNetworkOnly
+}
+
+// SetSummary sets whether the per-namespace footer is printed after the table for testing purposes.
+func (o *IPsOptions) SetSummary(summary bool) {
+	o.summary = summary
+}
+
+// SetCount enables printing a summary instead of the listing for testing purposes.
+func (o *IPsOptions) SetCount(count bool) {
+	o.count = count
+}
+
+// SetAlignedColumns prints tables with the built-in aligned printer for testing purposes.
+func (o *IPsOptions) SetAlignedColumns(alignedColumns bool) {
+	o.alignedColumns = alignedColumns
+}
+
+// SetMaxColumnWidth sets the width table cells are truncated to for testing purposes.
+func (o *IPsOptions) SetMaxColumnWidth(maxColumnWidth int) {
+	o.maxColumnWidth = maxColumnWidth
+}
+
+// SetColor sets the color mode for testing purposes.
+func (o *IPsOptions) SetColor(color string) {
+	o.color = color
+}
+
+// SetAllNamespaces lists pods from all namespaces for testing purposes.
+func (o *IPsOptions) SetAllNamespaces(allNamespaces bool) {
+	o.allNamespaces = allNamespaces
+	if allNamespaces {
+		o.namespace = ""
+	}
+}
+
+// SetLabelSelector sets the label selector for testing purposes.
+func (o *IPsOptions) SetLabelSelector(selector string) {
+	o.labelSelector = selector
+}
+
+// SetPodNameRegex sets the regular expression pod names must match for testing purposes.
+func (o *IPsOptions) SetPodNameRegex(podNameRegex string) {
+	o.podNameRegex = podNameRegex
+}
+
+// SetExcludePodRegex sets the regular expression of the pod names to leave out for testing purposes.
+func (o *IPsOptions) SetExcludePodRegex(excludePodRegex string) {
+	o.excludePodRegex = excludePodRegex
+}
+
+// SetStatuses sets the pod statuses to list for testing purposes.
+func (o *IPsOptions) SetStatuses(statuses []string) {
+	o.statuses = statuses
+}
+
+// SetNoDedup keeps IPs reported by several pods for testing purposes.
+func (o *IPsOptions) SetNoDedup(noDedup bool) {
+	o.noDedup = noDedup
+}
+
+// SetShowPending lists pods without an IP address for testing purposes.
+func (o *IPsOptions) SetShowPending(showPending bool) {
+	o.showPending = showPending
+}
+
+// SetShowAgeTimestamp prints the creation timestamp in the AGE column for testing purposes.
+func (o *IPsOptions) SetShowAgeTimestamp(showAgeTimestamp bool) {
+	o.showAgeTimestamp = showAgeTimestamp
+}
+
+// SetTimezone sets the time zone timestamps are printed in for testing purposes.
+func (o *IPsOptions) SetTimezone(timezone string) {
+	o.timezone = timezone
+}
+
+// SetOutputFile sets the file the output is written to for testing purposes.
+func (o *IPsOptions) SetOutputFile(outputFile string) {
+	o.outputFile = outputFile
+}
+
+// SetSelectorFile sets the file the label selector is read from for testing purposes.
+func (o *IPsOptions) SetSelectorFile(selectorFile string) {
+	o.selectorFile = selectorFile
+}
+
+// SetShowImages adds the IMAGES column for testing purposes.
+func (o *IPsOptions) SetShowImages(showImages bool) {
+	o.showImages = showImages
+}
+
+// SetGroupBy prints a section per node or namespace for testing purposes.
+func (o *IPsOptions) SetGroupBy(groupBy string) {
+	o.groupBy = groupBy
+}
+
+// SetShowDNS adds the DNS column with the given cluster domain for testing purposes.
+func (o *IPsOptions) SetShowDNS(showDNS bool, clusterDomain string) {
+	o.showDNS = showDNS
+	o.clusterDomain = clusterDomain
+}
+
+// SetNamespace sets the namespace to list pods from for testing purposes.
+func (o *IPsOptions) SetNamespace(namespace string) {
+	o.namespace = namespace
+}
+
+// SetClientset makes Run use the given client instead of one built from the kubeconfig, e.g. a fake clientset in tests.
+func (o *IPsOptions) SetClientset(clientset kubernetes.Interface) {
+	o.clientset = clientset
+}
+
+// SetContexts sets the kubeconfig contexts to list pods from for testing purposes.
+func (o *IPsOptions) SetContexts(contexts []string) {
+	o.contexts = contexts
+}
+
+// SetContextClientsets makes Run use the given clients for the named contexts instead of ones built from the
+// kubeconfig, e.g. fake clientsets in tests. A context missing from the map fails like an unknown context.
+func (o *IPsOptions) SetContextClientsets(clientsets map[string]kubernetes.Interface) {
+	o.contextClientsets = clientsets
+}
+
+// SetService selects the pods backing the named service for testing purposes.
+func (o *IPsOptions) SetService(service string) {
+	o.service = service
+}
+
+// SetIncludeEndpoints enables listing the endpoint addresses of the service for testing purposes.
+func (o *IPsOptions) SetIncludeEndpoints(includeEndpoints bool) {
+	o.includeEndpoints = includeEndpoints
+}
+
+// SetDualStack shows the IPv4 and IPv6 addresses of a pod in one row for testing purposes.
+func (o *IPsOptions) SetDualStack(dualStack bool) {
+	o.dualStack = dualStack
+}
+
+// SetSkipInvalidIPs sets whether malformed pod IPs are left out for testing purposes.
+func (o *IPsOptions) SetSkipInvalidIPs(skip bool) {
+	o.skipInvalidIPs = skip
+}
+
+// SetVerbose enables the diagnostic log on stderr for testing purposes.
+func (o *IPsOptions) SetVerbose(verbose bool) {
+	o.verbose = verbose
+}
+
+// SetFailOnEmpty makes finding no pods an error for testing purposes.
+func (o *IPsOptions) SetFailOnEmpty(failOnEmpty bool) {
+	o.failOnEmpty = failOnEmpty
+}
+
+// SetAllowMissingKeys sets whether templates print missing keys empty instead of failing for testing purposes.
+func (o *IPsOptions) SetAllowMissingKeys(allowMissingKeys bool) {
+	o.allowMissingKeys = allowMissingKeys
+}
+
+// SetQuiet suppresses the "No pods found" message for testing purposes.
+func (o *IPsOptions) SetQuiet(quiet bool) {
+	o.quiet = quiet
+}
+
+// SetHeaderStyle sets the case of the column headers for testing purposes.
+func (o *IPsOptions) SetHeaderStyle(style string) {
+	o.headerStyle = style
+}
+
+// SetSince keeps only the pods created within the duration for testing purposes.
+func (o *IPsOptions) SetSince(since time.Duration) {
+	o.since = since
+}
+
+// SetOlderThan keeps only the pods created longer ago than the duration for testing purposes.
+func (o *IPsOptions) SetOlderThan(olderThan time.Duration) {
+	o.olderThan = olderThan
+}
+
+// SetExpandIPv6 prints IPv6 addresses in full for testing purposes.
+func (o *IPsOptions) SetExpandIPv6(expand bool) {
+	o.expandIPv6 = expand
+}
+
+// SetNodes enables listing node addresses for testing purposes.
+func (o *IPsOptions) SetNodes(nodes bool) {
+	o.nodes = nodes
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// stdinFilename makes --filename read from stdin.
+const stdinFilename = "-"
+
+// readPods reads the pods from the --filename snapshot in place of the cluster, keeping those that the
+// namespace, pod names and label selector would select on the API server.
+func (o *IPsOptions) readPods(namespace string) (*corev1.PodList, error) {
+	reader := o.In
+	if o.filename != stdinFilename {
+		file, err := os.Open(o.filename)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open pods file: %w", err)
+		}
+		defer func() { _ = file.Close() }()
+		reader = file
+	}
+
+	pods, err := decodePods(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pods from %s: %w", o.filename, err)
+	}
+
+	selector, err := labels.Parse(o.labelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label selector %q: %w", o.labelSelector, err)
+	}
+
+	podList := &corev1.PodList{}
+	for _, pod := range pods {
+		// manifests without a namespace land in the requested one, as on the API server
+		if namespace != "" && pod.Namespace != "" && pod.Namespace != namespace {
+			continue
+		}
+		if !o.matchesPodNames(pod) || !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		podList.Items = append(podList.Items, *pod)
+	}
+
+	return podList, nil
+}
+
+// decodePods decodes a stream of YAML or JSON documents, such as the output of kubectl get pods -o yaml, into
+// the pods they hold. A document may be a Pod, a PodList or a List of them; other kinds are skipped.
+func decodePods(reader io.Reader) ([]*corev1.Pod, error) {
+	decoder := yaml.NewYAMLOrJSONDecoder(reader, 4096)
+
+	var pods []*corev1.Pod
+	for {
+		var raw runtime.RawExtension
+		if err := decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				return pods, nil
+			}
+
+			return nil, err
+		}
+		if len(raw.Raw) == 0 || string(raw.Raw) == "null" {
+			continue
+		}
+
+		decoded, err := decodeRawPods(raw.Raw)
+		if err != nil {
+			return nil, err
+		}
+		pods = append(pods, decoded...)
+	}
+}
+
+func decodeRawPods(data []byte) ([]*corev1.Pod, error) {
+	obj, _, err := scheme.Codecs.UniversalDeserializer().Decode(data, nil, nil)
+	if err != nil {
+		// kinds unknown to the scheme, such as custom resources, can't hold pods
+		if runtime.IsNotRegisteredError(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	switch obj := obj.(type) {
+	case *corev1.Pod:
+		return []*corev1.Pod{obj}, nil
+	case *corev1.PodList:
+		pods := make([]*corev1.Pod, 0, len(obj.Items))
+		for i := range obj.Items {
+			pods = append(pods, &obj.Items[i])
+		}
+
+		return pods, nil
+	case *corev1.List:
+		var pods []*corev1.Pod
+		for _, item := range obj.Items {
+			decoded, err := decodeRawPods(item.Raw)
+			if err != nil {
+				return nil, err
+			}
+			pods = append(pods, decoded...)
+		}
+
+		return pods, nil
+	default:
+		return nil, nil
+	}
+}
+
+// validateFilename rejects the flags that need the cluster when reading pods from --filename.
+func (o *IPsOptions) validateFilename() error {
+	if o.filename == "" {
+		return nil
+	}
+
+	switch {
+	case o.fieldSelector != "":
+		return fmt.Errorf("%w: --field-selector cannot be used with --filename", ErrIncompatibleFlags)
+	case o.watch:
+		return fmt.Errorf("%w: --watch cannot be used with --filename", ErrIncompatibleFlags)
+	case o.nodes:
+		return fmt.Errorf("%w: --nodes cannot be used with --filename", ErrIncompatibleFlags)
+	case len(o.contexts) > 0:
+		return fmt.Errorf("%w: --contexts cannot be used with --filename", ErrIncompatibleFlags)
+	case o.service != "":
+		return fmt.Errorf("%w: --service cannot be used with --filename", ErrIncompatibleFlags)
+	case o.includeServices:
+		return fmt.Errorf("%w: --include-services cannot be used with --filename", ErrIncompatibleFlags)
+	case len(o.nodeLabelColumns) > 0:
+		return fmt.Errorf("%w: --show-node-labels cannot be used with --filename", ErrIncompatibleFlags)
+	}
+
+	return nil
+}
@@ -1,12 +1,17 @@
 package cmd
 
 import (
+	"cmp"
 	"fmt"
+	"net"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/duration"
 )
@@ -16,17 +21,77 @@ const (
 	unknownValue = "<unknown>"
 )
 
+const (
+	podType      = "Pod"
+	serviceType  = "Service"
+	endpointType = "Endpoint"
+)
+
 // FormatPodAge returns the age of the pod in human-readable format.
 func FormatPodAge(pod *corev1.Pod) string {
-	if pod.CreationTimestamp.IsZero() {
+	return formatAge(pod.CreationTimestamp)
+}
+
+func formatAge(timestamp metav1.Time) string {
+	if timestamp.IsZero() {
+		return unknownValue
+	}
+
+	return duration.HumanDuration(time.Since(timestamp.Time))
+}
+
+// formatAgeColumn returns the AGE cell of a row, the RFC3339 creation timestamp in the requested location instead
+// of the relative age when ageTimestamp is set, so that archived output stays meaningful.
+func formatAgeColumn(timestamp metav1.Time, options columnOptions) string {
+	if !options.ageTimestamp {
+		return formatAge(timestamp)
+	}
+	if timestamp.IsZero() {
 		return unknownValue
 	}
 
-	return duration.HumanDuration(time.Since(pod.CreationTimestamp.Time))
+	return timestamp.In(cmp.Or(options.location, time.Local)).Format(time.RFC3339)
 }
 
-// FormatPodStatus returns the current status of the pod.
+// formatIP returns the IP cell of a row, <none> for a pod listed before it was assigned an address.
+func formatIP(ip string) string {
+	if ip == "" {
+		return noneValue
+	}
+
+	return ip
+}
+
+// expandIPv6 writes an IPv6 address in full with every group zero-padded, e.g. 0000:0000:0000:0000:0000:0000:0000:0001
+// for ::1, leaving IPv4 addresses and unparsable values as they are.
+func expandIPv6(ip string) string {
+	parsed := net.ParseIP(ip)
+	if parsed == nil || parsed.To4() != nil {
+		return ip
+	}
+
+	groups := make([]string, 0, net.IPv6len/2)
+	for i := 0; i < net.IPv6len; i += 2 {
+		groups = append(groups, fmt.Sprintf("%02x%02x", parsed[i], parsed[i+1]))
+	}
+
+	return strings.Join(groups, ":")
+}
+
+// FormatPodStatus returns the current status of the pod for display. A running pod held back by a readiness gate
+// is reported as "Running (NotReady)", as its containers alone look healthy.
 func FormatPodStatus(pod *corev1.Pod) string {
+	status := podStatus(pod)
+	if status == string(corev1.PodRunning) && failsReadinessGate(pod) {
+		status += " (NotReady)"
+	}
+
+	return status
+}
+
+// podStatus returns the current status of the pod the way kubectl reports it, which --status and --running-only
+// match against.
+func podStatus(pod *corev1.Pod) string {
 	reason := string(pod.Status.Phase)
 	if pod.Status.Reason != "" {
 		reason = pod.Status.Reason
@@ -44,6 +109,28 @@ func FormatPodStatus(pod *corev1.Pod) string {
 	return handlePodDeletion(pod, reason)
 }
 
+// failsReadinessGate reports whether the pod is not ready because one of its readiness gates is not satisfied.
+func failsReadinessGate(pod *corev1.Pod) bool {
+	if len(pod.Spec.ReadinessGates) == 0 || podConditionStatus(pod, corev1.PodReady) != corev1.ConditionFalse {
+		return false
+	}
+
+	return slices.ContainsFunc(pod.Spec.ReadinessGates, func(gate corev1.PodReadinessGate) bool {
+		return podConditionStatus(pod, gate.ConditionType) != corev1.ConditionTrue
+	})
+}
+
+// podConditionStatus returns the status of the pod condition, or an empty status when the pod does not report it.
+func podConditionStatus(pod *corev1.Pod, conditionType corev1.PodConditionType) corev1.ConditionStatus {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == conditionType {
+			return condition.Status
+		}
+	}
+
+	return ""
+}
+
 func checkInitContainers(pod *corev1.Pod) (string, bool) {
 	for i := range pod.Status.InitContainerStatuses {
 		container := pod.Status.InitContainerStatuses[i]
@@ -149,14 +236,112 @@ func FormatPodReady(pod *corev1.Pod) string {
 	return fmt.Sprintf("%d/%d", readyContainers, totalContainers)
 }
 
+// FormatContainerReady returns whether the named container of the pod is ready, as 1/1 or 0/1.
+func FormatContainerReady(pod *corev1.Pod, container string) string {
+	if status := containerStatus(pod, container); status != nil && status.Ready {
+		return "1/1"
+	}
+
+	return "0/1"
+}
+
+// FormatContainerRestarts returns the number of restarts of the named container of the pod.
+func FormatContainerRestarts(pod *corev1.Pod, container string) string {
+	if status := containerStatus(pod, container); status != nil {
+		return strconv.Itoa(int(status.RestartCount))
+	}
+
+	return "0"
+}
+
+func containerStatus(pod *corev1.Pod, container string) *corev1.ContainerStatus {
+	for i := range pod.Status.ContainerStatuses {
+		if pod.Status.ContainerStatuses[i].Name == container {
+			return &pod.Status.ContainerStatuses[i]
+		}
+	}
+
+	return nil
+}
+
 // FormatRestarts returns the total number of container restarts in the pod.
 func FormatRestarts(pod *corev1.Pod) string {
+	return strconv.Itoa(int(totalRestarts(pod)))
+}
+
+// FormatRestartsWithInit returns the total number of container restarts in the pod, including init containers.
+func FormatRestartsWithInit(pod *corev1.Pod) string {
+	return strconv.Itoa(int(podRestarts(pod, true)))
+}
+
+// FormatRestartsWithLastRestart returns the number of restarts in the pod followed by how long ago the most
+// recent one happened, like kubectl does, e.g. "5 (3m ago)". Pods without restarts get no suffix.
+func FormatRestartsWithLastRestart(pod *corev1.Pod, includeInit bool) string {
+	statuses := pod.Status.ContainerStatuses
+	if includeInit {
+		statuses = slices.Concat(pod.Status.InitContainerStatuses, statuses)
+	}
+
+	return withLastRestart(strconv.Itoa(int(podRestarts(pod, includeInit))), lastRestartTime(statuses))
+}
+
+// withLastRestart appends the age of the last restart to the restart count, if there was one.
+func withLastRestart(restarts string, lastRestart metav1.Time) string {
+	if lastRestart.IsZero() {
+		return restarts
+	}
+
+	return fmt.Sprintf("%s (%s ago)", restarts, duration.HumanDuration(time.Since(lastRestart.Time)))
+}
+
+// lastRestartTime returns when the most recently restarted container last terminated, or zero without restarts.
+func lastRestartTime(statuses []corev1.ContainerStatus) metav1.Time {
+	var last metav1.Time
+	for i := range statuses {
+		terminated := statuses[i].LastTerminationState.Terminated
+		if statuses[i].RestartCount == 0 || terminated == nil {
+			continue
+		}
+		if last.Before(&terminated.FinishedAt) {
+			last = terminated.FinishedAt
+		}
+	}
+
+	return last
+}
+
+func podRestarts(pod *corev1.Pod, includeInit bool) int32 {
+	restarts := totalRestarts(pod)
+	if includeInit {
+		for i := range pod.Status.InitContainerStatuses {
+			restarts += pod.Status.InitContainerStatuses[i].RestartCount
+		}
+	}
+
+	return restarts
+}
+
+func totalRestarts(pod *corev1.Pod) int32 {
 	restarts := int32(0)
 	for i := range pod.Status.ContainerStatuses {
 		restarts += pod.Status.ContainerStatuses[i].RestartCount
 	}
 
-	return strconv.Itoa(int(restarts))
+	return restarts
+}
+
+// FormatValues returns the value stored under each of the keys, or <none> when the key is absent.
+func FormatValues(values map[string]string, keys []string) []any {
+	cells := make([]any, 0, len(keys))
+	for _, key := range keys {
+		value, ok := values[key]
+		if !ok {
+			value = noneValue
+		}
+		cells = append(cells, value)
+	}
+
+	return cells
 }
 
 // FormatLabels formats a map of labels into a comma-separated key=value string.
@@ -181,32 +366,445 @@ func GetNodeName(pod *corev1.Pod) string {
 	return noneValue
 }
 
-func makeTableRow(pod *corev1.Pod, ip string, showNamespace, wide, showLabels bool) []any {
+// GetHostIP returns the IP address of the node where the pod is running.
+func GetHostIP(pod *corev1.Pod) string {
+	if pod.Status.HostIP != "" {
+		return pod.Status.HostIP
+	}
+
+	return noneValue
+}
+
+// FormatOwner returns the workload owning the pod as kind/name, resolving ReplicaSets created by a
+// Deployment back to the Deployment.
+func FormatOwner(pod *corev1.Pod) string {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		if len(pod.OwnerReferences) == 0 {
+			return noneValue
+		}
+		owner = &pod.OwnerReferences[0]
+	}
+
+	kind, name := owner.Kind, owner.Name
+	if kind == "ReplicaSet" {
+		// a Deployment names its ReplicaSets after itself plus the pod template hash
+		if hash := pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey]; hash != "" {
+			if deployment, ok := strings.CutSuffix(name, "-"+hash); ok {
+				kind, name = "Deployment", deployment
+			}
+		}
+	}
+
+	return kind + "/" + name
+}
+
+// FormatQoS returns the quality of service class assigned to the pod.
+func FormatQoS(pod *corev1.Pod) string {
+	if pod.Status.QOSClass == "" {
+		return unknownValue
+	}
+
+	return string(pod.Status.QOSClass)
+}
+
+// FormatPriorityClass returns the priority class of the pod followed by the priority it resolved to, e.g.
+// system-node-critical (2000001000), or <none> when the pod names no priority class.
+func FormatPriorityClass(pod *corev1.Pod) string {
+	if pod.Spec.PriorityClassName == "" {
+		return noneValue
+	}
+	if pod.Spec.Priority == nil {
+		return pod.Spec.PriorityClassName
+	}
+
+	return fmt.Sprintf("%s (%d)", pod.Spec.PriorityClassName, *pod.Spec.Priority)
+}
+
+// FormatIPFamily returns IPv4 or IPv6 depending on the family of the given IP address.
+func FormatIPFamily(ip string) string {
+	if ip == "" {
+		return noneValue
+	}
+
+	parsed := net.ParseIP(ip)
+	switch {
+	case parsed == nil:
+		return unknownValue
+	case parsed.To4() != nil:
+		return "IPv4"
+	default:
+		return "IPv6"
+	}
+}
+
+// FormatPodDNS returns the DNS name of the pod's A or AAAA record for the given IP, with the IP dashed.
+func FormatPodDNS(pod *corev1.Pod, ip, domain string) string {
+	if ip == "" {
+		return noneValue
+	}
+
+	dashed := strings.NewReplacer(".", "-", ":", "-").Replace(ip)
+
+	return fmt.Sprintf("%s.%s.pod.%s", dashed, pod.Namespace, domain)
+}
+
+// FormatImages returns the images of the pod's containers as a comma-separated list, in the order of the
+// containers.
+func FormatImages(pod *corev1.Pod) string {
+	images := make([]string, 0, len(pod.Spec.Containers))
+	for i := range pod.Spec.Containers {
+		images = append(images, pod.Spec.Containers[i].Image)
+	}
+
+	if len(images) == 0 {
+		return noneValue
+	}
+
+	return strings.Join(images, ",")
+}
+
+// formatContainerImage returns the image of the named container, or every image of the pod for a row that is not
+// expanded per container.
+func formatContainerImage(pod *corev1.Pod, container string) string {
+	if container == "" {
+		return FormatImages(pod)
+	}
+	for i := range pod.Spec.Containers {
+		if pod.Spec.Containers[i].Name == container {
+			return cmp.Or(pod.Spec.Containers[i].Image, noneValue)
+		}
+	}
+
+	return noneValue
+}
+
+// FormatPorts returns the container ports declared by the pod as a comma-separated port/protocol list.
+func FormatPorts(pod *corev1.Pod) string {
+	ports := []string{}
+	for i := range pod.Spec.Containers {
+		for _, port := range pod.Spec.Containers[i].Ports {
+			protocol := port.Protocol
+			if protocol == "" {
+				protocol = corev1.ProtocolTCP
+			}
+			ports = append(ports, fmt.Sprintf("%d/%s", port.ContainerPort, protocol))
+		}
+	}
+
+	if len(ports) == 0 {
+		return noneValue
+	}
+
+	return strings.Join(ports, ",")
+}
+
+// FormatServicePorts returns the ports exposed by the service as a comma-separated port/protocol list.
+func FormatServicePorts(service *corev1.Service) string {
+	if len(service.Spec.Ports) == 0 {
+		return noneValue
+	}
+
+	ports := make([]string, 0, len(service.Spec.Ports))
+	for _, port := range service.Spec.Ports {
+		protocol := port.Protocol
+		if protocol == "" {
+			protocol = corev1.ProtocolTCP
+		}
+		ports = append(ports, fmt.Sprintf("%d/%s", port.Port, protocol))
+	}
+
+	return strings.Join(ports, ",")
+}
+
+// FormatNodeAddresses returns the node addresses of the given type as a comma-separated list.
+func FormatNodeAddresses(addresses []corev1.NodeAddress, addressType corev1.NodeAddressType) string {
+	var matching []string
+	for _, address := range addresses {
+		if address.Type == addressType {
+			matching = append(matching, address.Address)
+		}
+	}
+	if len(matching) == 0 {
+		return noneValue
+	}
+
+	return strings.Join(matching, ",")
+}
+
+// formatIPColumns returns the IP cell, or the IPv4 and IPv6 cells in the dual-stack layout.
+func formatIPColumns(ip, ipv6 string, options columnOptions) []any {
+	if options.expandIPv6 {
+		ip, ipv6 = expandIPv6(ip), expandIPv6(ipv6)
+	}
+
+	if options.dualStack {
+		return []any{formatIP(ip), formatIP(ipv6)}
+	}
+
+	return []any{formatIP(ip)}
+}
+
+// formatSecondaryIPs returns the SECONDARY-IPS cell of a row, the addresses after the primary one separated by
+// commas, or <none> for single-stack pods.
+func formatSecondaryIPs(ips []string, options columnOptions) string {
+	if len(ips) == 0 {
+		return noneValue
+	}
+
+	formatted := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		if options.expandIPv6 {
+			ip = expandIPv6(ip)
+		}
+		formatted = append(formatted, ip)
+	}
+
+	return strings.Join(formatted, ",")
+}
+
+// makeTableRow returns the cells of a pod row, describing the named container when containers are expanded.
+// The ipv6 address is only set in the dual-stack layout, where ip holds the IPv4 address.
+func makeTableRow(pod *corev1.Pod, ip, ipv6, container string, options columnOptions) []any {
 	row := []any{}
 
-	if showNamespace {
+	if options.showNamespace {
 		row = append(row, pod.Namespace)
 	}
 
-	row = append(row, pod.Name, ip, FormatPodStatus(pod))
+	row = append(row, pod.Name)
+
+	if options.containers {
+		row = append(row, cmp.Or(container, noneValue))
+	}
+
+	row = append(row, formatIPColumns(ip, ipv6, options)...)
+
+	if options.showType {
+		row = append(row, podType)
+	}
+
+	row = append(row, FormatPodStatus(pod))
+
+	// wide output tells when the last restart happened, keeping the plain count elsewhere
+	if options.containers && container != "" {
+		restarts := FormatContainerRestarts(pod, container)
+		if status := containerStatus(pod, container); options.wide && status != nil {
+			restarts = withLastRestart(restarts, lastRestartTime([]corev1.ContainerStatus{*status}))
+		}
+		row = append(row, FormatContainerReady(pod, container), restarts)
+	} else if options.wide || options.containers {
+		restarts := FormatRestarts(pod)
+		switch {
+		case options.wide:
+			restarts = FormatRestartsWithLastRestart(pod, options.includeInitRestarts)
+		case options.includeInitRestarts:
+			restarts = FormatRestartsWithInit(pod)
+		}
+		row = append(row, FormatPodReady(pod), restarts)
+	}
+
+	if options.wide {
+		row = append(row, GetNodeName(pod), GetHostIP(pod), FormatOwner(pod), FormatQoS(pod), FormatPriorityClass(pod))
+		if !options.dualStack {
+			row = append(row, FormatIPFamily(ip))
+		}
+	}
+
+	row = append(row, formatAgeColumn(pod.CreationTimestamp, options))
+
+	if options.showPorts {
+		row = append(row, FormatPorts(pod))
+	}
+
+	if options.showImages {
+		row = append(row, formatContainerImage(pod, container))
+	}
 
-	if wide {
-		row = append(row, FormatPodReady(pod), FormatRestarts(pod), GetNodeName(pod))
+	if options.showDNS {
+		row = append(row, FormatPodDNS(pod, cmp.Or(ip, ipv6), options.clusterDomain))
 	}
 
-	row = append(row, FormatPodAge(pod))
+	row = append(row, FormatValues(pod.Labels, options.labelColumns)...)
+	row = append(row, FormatValues(pod.Annotations, options.annotationColumns)...)
 
-	if showLabels {
+	if options.showLabels {
 		row = append(row, FormatLabels(pod.Labels))
 	}
 
 	return row
 }
 
-func makeTableHeaders(showNamespace, wide, showLabels bool) []metav1.TableColumnDefinition {
+// makeServiceTableRow fills the pod-specific columns of a service row with <none>.
+func makeServiceTableRow(service *corev1.Service, ip, ipv6 string, options columnOptions) []any {
+	row := []any{}
+
+	if options.showNamespace {
+		row = append(row, service.Namespace)
+	}
+
+	row = append(row, service.Name)
+
+	if options.containers {
+		row = append(row, noneValue)
+	}
+
+	row = append(row, formatIPColumns(ip, ipv6, options)...)
+
+	if options.showType {
+		row = append(row, serviceType)
+	}
+
+	row = append(row, noneValue)
+
+	if options.wide || options.containers {
+		row = append(row, noneValue, noneValue)
+	}
+
+	if options.wide {
+		row = append(row, noneValue, noneValue, noneValue, noneValue, noneValue)
+		if !options.dualStack {
+			row = append(row, FormatIPFamily(ip))
+		}
+	}
+
+	row = append(row, formatAgeColumn(service.CreationTimestamp, options))
+
+	if options.showPorts {
+		row = append(row, FormatServicePorts(service))
+	}
+
+	if options.showImages {
+		row = append(row, noneValue)
+	}
+
+	if options.showDNS {
+		row = append(row, fmt.Sprintf("%s.%s.svc.%s", service.Name, service.Namespace, options.clusterDomain))
+	}
+
+	row = append(row, FormatValues(service.Labels, options.labelColumns)...)
+	row = append(row, FormatValues(service.Annotations, options.annotationColumns)...)
+
+	if options.showLabels {
+		row = append(row, FormatLabels(service.Labels))
+	}
+
+	return row
+}
+
+// makeEndpointTableRow describes an endpoint slice address, showing its readiness as the status and the service
+// it belongs to as the owner.
+func makeEndpointTableRow(
+	slice *discoveryv1.EndpointSlice, endpoint *discoveryv1.Endpoint, ip, ipv6 string, options columnOptions,
+) []any {
+	row := []any{}
+
+	if options.showNamespace {
+		row = append(row, slice.Namespace)
+	}
+
+	row = append(row, slice.Name)
+
+	if options.containers {
+		row = append(row, noneValue)
+	}
+
+	row = append(row, formatIPColumns(ip, ipv6, options)...)
+
+	if options.showType {
+		row = append(row, endpointType)
+	}
+
+	row = append(row, FormatEndpointStatus(endpoint))
+
+	if options.wide || options.containers {
+		row = append(row, noneValue, noneValue)
+	}
+
+	if options.wide {
+		node, owner := noneValue, noneValue
+		if endpoint.NodeName != nil && *endpoint.NodeName != "" {
+			node = *endpoint.NodeName
+		}
+		if service := slice.Labels[discoveryv1.LabelServiceName]; service != "" {
+			owner = serviceType + "/" + service
+		}
+		row = append(row, node, noneValue, owner, noneValue, noneValue)
+		if !options.dualStack {
+			row = append(row, FormatIPFamily(ip))
+		}
+	}
+
+	row = append(row, formatAgeColumn(slice.CreationTimestamp, options))
+
+	if options.showPorts {
+		row = append(row, FormatEndpointPorts(slice))
+	}
+
+	if options.showImages {
+		row = append(row, noneValue)
+	}
+
+	if options.showDNS {
+		row = append(row, noneValue)
+	}
+
+	row = append(row, FormatValues(slice.Labels, options.labelColumns)...)
+	row = append(row, FormatValues(slice.Annotations, options.annotationColumns)...)
+
+	if options.showLabels {
+		row = append(row, FormatLabels(slice.Labels))
+	}
+
+	return row
+}
+
+// FormatEndpointStatus returns Terminating, NotReady or Ready from the endpoint conditions, taking an unknown
+// readiness as ready like the API does.
+func FormatEndpointStatus(endpoint *discoveryv1.Endpoint) string {
+	conditions := endpoint.Conditions
+	switch {
+	case conditions.Terminating != nil && *conditions.Terminating:
+		return "Terminating"
+	case conditions.Ready != nil && !*conditions.Ready:
+		return "NotReady"
+	default:
+		return "Ready"
+	}
+}
+
+// FormatEndpointPorts returns the ports of the endpoint slice as a comma-separated port/protocol list.
+func FormatEndpointPorts(slice *discoveryv1.EndpointSlice) string {
+	ports := make([]string, 0, len(slice.Ports))
+	for _, port := range slice.Ports {
+		if port.Port == nil {
+			continue
+		}
+		protocol := corev1.ProtocolTCP
+		if port.Protocol != nil {
+			protocol = *port.Protocol
+		}
+		ports = append(ports, fmt.Sprintf("%d/%s", *port.Port, protocol))
+	}
+
+	if len(ports) == 0 {
+		return noneValue
+	}
+
+	return strings.Join(ports, ",")
+}
+
+func makeTableHeaders(options columnOptions) []metav1.TableColumnDefinition {
 	columns := []metav1.TableColumnDefinition{}
 
-	if showNamespace {
+	if options.showCluster {
+		columns = append(columns, metav1.TableColumnDefinition{
+			Name: "CLUSTER",
+			Type: "string",
+		})
+	}
+
+	if options.showNamespace {
 		columns = append(columns, metav1.TableColumnDefinition{
 			Name: "NAMESPACE",
 			Type: "string",
@@ -218,34 +816,115 @@ func makeTableHeaders(showNamespace, wide, showLabels bool) []metav1.TableColumn
 			Name: "NAME",
 			Type: "string",
 		},
-		metav1.TableColumnDefinition{
+	)
+
+	if options.containers {
+		columns = append(columns, metav1.TableColumnDefinition{
+			Name: "CONTAINER",
+			Type: "string",
+		})
+	}
+
+	if options.dualStack {
+		columns = append(columns,
+			metav1.TableColumnDefinition{
+				Name: "IPV4",
+				Type: "string",
+			},
+			metav1.TableColumnDefinition{
+				Name: "IPV6",
+				Type: "string",
+			},
+		)
+	} else {
+		columns = append(columns, metav1.TableColumnDefinition{
 			Name: "IP",
 			Type: "string",
-		},
-		metav1.TableColumnDefinition{
-			Name: "STATUS",
+		})
+	}
+
+	if options.secondaryIPs {
+		columns = append(columns, metav1.TableColumnDefinition{
+			Name: "SECONDARY-IPS",
 			Type: "string",
-		},
-	)
+		})
+	}
+
+	if options.showInterface {
+		columns = append(columns, metav1.TableColumnDefinition{
+			Name: "INTERFACE",
+			Type: "string",
+		})
+	}
+
+	if options.showType {
+		columns = append(columns, metav1.TableColumnDefinition{
+			Name: "TYPE",
+			Type: "string",
+		})
+	}
+
+	columns = append(columns, metav1.TableColumnDefinition{
+		Name: "STATUS",
+		Type: "string",
+	})
 
-	if wide {
+	// per-container readiness is the point of expanding containers, so it is shown outside wide output too
+	if options.wide || options.containers {
+		priority := int32(1)
+		if options.containers {
+			priority = 0
+		}
 		columns = append(columns,
 			metav1.TableColumnDefinition{
 				Name:     "READY",
 				Type:     "string",
-				Priority: 1,
+				Priority: priority,
 			},
 			metav1.TableColumnDefinition{
 				Name:     "RESTARTS",
 				Type:     "string",
-				Priority: 1,
+				Priority: priority,
 			},
+		)
+	}
+
+	if options.wide {
+		columns = append(columns,
 			metav1.TableColumnDefinition{
 				Name:     "NODE",
 				Type:     "string",
 				Priority: 1,
 			},
+			metav1.TableColumnDefinition{
+				Name:     "HOST-IP",
+				Type:     "string",
+				Priority: 1,
+			},
+			metav1.TableColumnDefinition{
+				Name:     "OWNER",
+				Type:     "string",
+				Priority: 1,
+			},
+			metav1.TableColumnDefinition{
+				Name:     "QOS",
+				Type:     "string",
+				Priority: 1,
+			},
+			metav1.TableColumnDefinition{
+				Name:     "PRIORITY-CLASS",
+				Type:     "string",
+				Priority: 1,
+			},
 		)
+		// the dual-stack layout names the family of every address in its column
+		if !options.dualStack {
+			columns = append(columns, metav1.TableColumnDefinition{
+				Name:     "FAMILY",
+				Type:     "string",
+				Priority: 1,
+			})
+		}
 	}
 
 	columns = append(columns, metav1.TableColumnDefinition{
@@ -253,7 +932,32 @@ func makeTableHeaders(showNamespace, wide, showLabels bool) []metav1.TableColumn
 		Type: "string",
 	})
 
-	if showLabels {
+	if options.showPorts {
+		columns = append(columns, metav1.TableColumnDefinition{
+			Name: "PORTS",
+			Type: "string",
+		})
+	}
+
+	if options.showImages {
+		columns = append(columns, metav1.TableColumnDefinition{
+			Name: "IMAGES",
+			Type: "string",
+		})
+	}
+
+	if options.showDNS {
+		columns = append(columns, metav1.TableColumnDefinition{
+			Name: "DNS",
+			Type: "string",
+		})
+	}
+
+	columns = append(columns, makeKeyColumns(options.labelColumns)...)
+	columns = append(columns, makeKeyColumns(options.annotationColumns)...)
+	columns = append(columns, makeKeyColumns(options.nodeLabelColumns)...)
+
+	if options.showLabels {
 		columns = append(columns, metav1.TableColumnDefinition{
 			Name:     "LABELS",
 			Type:     "string",
@@ -263,3 +967,17 @@ func makeTableHeaders(showNamespace, wide, showLabels bool) []metav1.TableColumn
 
 	return columns
 }
+
+// makeKeyColumns returns a column per label or annotation key, named after the whole upper-cased key, e.g.
+// TEAM.EXAMPLE.COM/OWNER for team.example.com/owner, so that it cannot be mistaken for a built-in column.
+func makeKeyColumns(keys []string) []metav1.TableColumnDefinition {
+	columns := make([]metav1.TableColumnDefinition, 0, len(keys))
+	for _, key := range keys {
+		columns = append(columns, metav1.TableColumnDefinition{
+			Name: strings.ToUpper(key),
+			Type: "string",
+		})
+	}
+
+	return columns
+}
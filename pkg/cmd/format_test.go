@@ -7,6 +7,7 @@ import (
 	"github.com/andreygrechin/kubectl-ips/pkg/cmd"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -43,6 +44,27 @@ func TestFormatPodAge(t *testing.T) {
 	}
 }
 
+// newGatedPod returns a running pod with a load balancer readiness gate and the given Ready and gate conditions.
+func newGatedPod(ready, gate corev1.ConditionStatus) *corev1.Pod {
+	const gateCondition = corev1.PodConditionType("target-health.elbv2.k8s.aws/web")
+
+	return &corev1.Pod{
+		Spec: corev1.PodSpec{
+			ReadinessGates: []corev1.PodReadinessGate{{ConditionType: gateCondition}},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: ready},
+				{Type: gateCondition, Status: gate},
+			},
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "web", Ready: true, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+			},
+		},
+	}
+}
+
 func TestFormatPodStatus(t *testing.T) {
 	tests := map[string]struct {
 		pod      *corev1.Pod
@@ -91,6 +113,32 @@ func TestFormatPodStatus(t *testing.T) {
 			},
 			expected: "Terminating",
 		},
+		"running pod with failing readiness gate": {
+			pod:      newGatedPod(corev1.ConditionFalse, corev1.ConditionFalse),
+			expected: "Running (NotReady)",
+		},
+		"running pod with unreported readiness gate": {
+			pod: func() *corev1.Pod {
+				pod := newGatedPod(corev1.ConditionFalse, "")
+				pod.Status.Conditions = pod.Status.Conditions[:1]
+
+				return pod
+			}(),
+			expected: "Running (NotReady)",
+		},
+		"running pod with passing readiness gate": {
+			pod:      newGatedPod(corev1.ConditionTrue, corev1.ConditionTrue),
+			expected: "Running",
+		},
+		"running pod not ready without readiness gate": {
+			pod: func() *corev1.Pod {
+				pod := newGatedPod(corev1.ConditionFalse, corev1.ConditionTrue)
+				pod.Spec.ReadinessGates = nil
+
+				return pod
+			}(),
+			expected: "Running",
+		},
 	}
 
 	for name, tc := range tests {
@@ -156,10 +204,54 @@ func TestFormatPodReady(t *testing.T) {
 	}
 }
 
+func TestFormatContainerReadyAndRestarts(t *testing.T) {
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app"}, {Name: "sidecar"}, {Name: "starting"}},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "app", Ready: true, RestartCount: 1},
+				{Name: "sidecar", Ready: false, RestartCount: 4},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		container        string
+		expectedReady    string
+		expectedRestarts string
+	}{
+		"ready container": {
+			container:        "app",
+			expectedReady:    "1/1",
+			expectedRestarts: "1",
+		},
+		"not ready container": {
+			container:        "sidecar",
+			expectedReady:    "0/1",
+			expectedRestarts: "4",
+		},
+		"container without status": {
+			container:        "starting",
+			expectedReady:    "0/1",
+			expectedRestarts: "0",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedReady, cmd.FormatContainerReady(pod, tc.container))
+			assert.Equal(t, tc.expectedRestarts, cmd.FormatContainerRestarts(pod, tc.container))
+		})
+	}
+}
+
 func TestFormatRestarts(t *testing.T) {
 	tests := map[string]struct {
-		pod      *corev1.Pod
-		expected string
+		pod              *corev1.Pod
+		expected         string
+		expectedWithInit string
 	}{
 		"no restarts": {
 			pod: &corev1.Pod{
@@ -170,7 +262,8 @@ func TestFormatRestarts(t *testing.T) {
 					},
 				},
 			},
-			expected: "0",
+			expected:         "0",
+			expectedWithInit: "0",
 		},
 		"multiple restarts": {
 			pod: &corev1.Pod{
@@ -182,14 +275,142 @@ func TestFormatRestarts(t *testing.T) {
 					},
 				},
 			},
-			expected: "6",
+			expected:         "6",
+			expectedWithInit: "6",
+		},
+		"crash-looping init container": {
+			pod: &corev1.Pod{
+				Status: corev1.PodStatus{
+					InitContainerStatuses: []corev1.ContainerStatus{
+						{RestartCount: 7},
+					},
+					ContainerStatuses: []corev1.ContainerStatus{
+						{RestartCount: 0},
+					},
+				},
+			},
+			expected:         "0",
+			expectedWithInit: "7",
+		},
+		"init and main container restarts": {
+			pod: &corev1.Pod{
+				Status: corev1.PodStatus{
+					InitContainerStatuses: []corev1.ContainerStatus{
+						{RestartCount: 1},
+						{RestartCount: 2},
+					},
+					ContainerStatuses: []corev1.ContainerStatus{
+						{RestartCount: 3},
+						{RestartCount: 4},
+					},
+				},
+			},
+			expected:         "7",
+			expectedWithInit: "10",
 		},
 	}
 
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
-			result := cmd.FormatRestarts(tc.pod)
-			assert.Equal(t, tc.expected, result)
+			assert.Equal(t, tc.expected, cmd.FormatRestarts(tc.pod))
+			assert.Equal(t, tc.expectedWithInit, cmd.FormatRestartsWithInit(tc.pod))
+		})
+	}
+}
+
+func TestFormatRestartsWithLastRestart(t *testing.T) {
+	terminatedAgo := func(ago time.Duration) corev1.ContainerState {
+		return corev1.ContainerState{
+			Terminated: &corev1.ContainerStateTerminated{FinishedAt: metav1.NewTime(time.Now().Add(-ago))},
+		}
+	}
+
+	tests := map[string]struct {
+		status      corev1.PodStatus
+		includeInit bool
+		expected    string
+	}{
+		"no restarts": {
+			status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{RestartCount: 0}},
+			},
+			expected: "0",
+		},
+		"most recent restart": {
+			status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{
+					{RestartCount: 2, LastTerminationState: terminatedAgo(10 * time.Minute)},
+					{RestartCount: 3, LastTerminationState: terminatedAgo(3 * time.Minute)},
+				},
+			},
+			expected: "5 (3m ago)",
+		},
+		"restarts without termination state": {
+			status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{RestartCount: 2}},
+			},
+			expected: "2",
+		},
+		"init container restart ignored": {
+			status: corev1.PodStatus{
+				InitContainerStatuses: []corev1.ContainerStatus{
+					{RestartCount: 4, LastTerminationState: terminatedAgo(time.Minute)},
+				},
+				ContainerStatuses: []corev1.ContainerStatus{
+					{RestartCount: 1, LastTerminationState: terminatedAgo(2 * time.Hour)},
+				},
+			},
+			expected: "1 (120m ago)",
+		},
+		"init container restart included": {
+			status: corev1.PodStatus{
+				InitContainerStatuses: []corev1.ContainerStatus{
+					{RestartCount: 4, LastTerminationState: terminatedAgo(time.Minute)},
+				},
+				ContainerStatuses: []corev1.ContainerStatus{
+					{RestartCount: 1, LastTerminationState: terminatedAgo(2 * time.Hour)},
+				},
+			},
+			includeInit: true,
+			expected:    "5 (60s ago)",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			pod := &corev1.Pod{Status: tc.status}
+			assert.Equal(t, tc.expected, cmd.FormatRestartsWithLastRestart(pod, tc.includeInit))
+		})
+	}
+}
+
+func TestFormatValues(t *testing.T) {
+	values := map[string]string{
+		"team.example.com/owner": "payments",
+		"empty":                  "",
+	}
+
+	tests := map[string]struct {
+		keys     []string
+		expected []any
+	}{
+		"no keys": {
+			keys:     nil,
+			expected: []any{},
+		},
+		"present and absent keys": {
+			keys:     []string{"team.example.com/owner", "team.example.com/oncall"},
+			expected: []any{"payments", "<none>"},
+		},
+		"empty value": {
+			keys:     []string{"empty"},
+			expected: []any{""},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, cmd.FormatValues(values, tc.keys))
 		})
 	}
 }
@@ -257,3 +478,356 @@ func TestGetNodeName(t *testing.T) {
 		})
 	}
 }
+
+func TestGetHostIP(t *testing.T) {
+	tests := map[string]struct {
+		pod      *corev1.Pod
+		expected string
+	}{
+		"pod with host ip": {
+			pod: &corev1.Pod{
+				Status: corev1.PodStatus{
+					HostIP: "192.168.1.10",
+				},
+			},
+			expected: "192.168.1.10",
+		},
+		"pod without host ip": {
+			pod: &corev1.Pod{
+				Status: corev1.PodStatus{},
+			},
+			expected: "<none>",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := cmd.GetHostIP(tc.pod)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestFormatPorts(t *testing.T) {
+	tests := map[string]struct {
+		pod      *corev1.Pod
+		expected string
+	}{
+		"no ports": {
+			pod: &corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "app"}},
+				},
+			},
+			expected: "<none>",
+		},
+		"ports across containers": {
+			pod: &corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "web",
+							Ports: []corev1.ContainerPort{
+								{ContainerPort: 80, Protocol: corev1.ProtocolTCP},
+								{ContainerPort: 443},
+							},
+						},
+						{
+							Name:  "dns",
+							Ports: []corev1.ContainerPort{{ContainerPort: 53, Protocol: corev1.ProtocolUDP}},
+						},
+					},
+				},
+			},
+			expected: "80/TCP,443/TCP,53/UDP",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := cmd.FormatPorts(tc.pod)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestFormatImages(t *testing.T) {
+	tests := map[string]struct {
+		pod      *corev1.Pod
+		expected string
+	}{
+		"no containers": {
+			pod:      &corev1.Pod{},
+			expected: "<none>",
+		},
+		"images across containers": {
+			pod: &corev1.Pod{
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{{Name: "migrate", Image: "migrate:1.0"}},
+					Containers: []corev1.Container{
+						{Name: "web", Image: "nginx:1.27"},
+						{Name: "proxy", Image: "envoyproxy/envoy:v1.31"},
+					},
+				},
+			},
+			expected: "nginx:1.27,envoyproxy/envoy:v1.31",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := cmd.FormatImages(tc.pod)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestFormatServicePorts(t *testing.T) {
+	tests := map[string]struct {
+		service  *corev1.Service
+		expected string
+	}{
+		"no ports": {
+			service:  &corev1.Service{},
+			expected: "<none>",
+		},
+		"default protocol": {
+			service: &corev1.Service{
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{
+						{Port: 80},
+						{Port: 53, Protocol: corev1.ProtocolUDP},
+					},
+				},
+			},
+			expected: "80/TCP,53/UDP",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := cmd.FormatServicePorts(tc.service)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestFormatEndpointStatusAndPorts(t *testing.T) {
+	ready, notReady := true, false
+	tests := map[string]struct {
+		conditions discoveryv1.EndpointConditions
+		expected   string
+	}{
+		"unknown readiness": {
+			expected: "Ready",
+		},
+		"ready": {
+			conditions: discoveryv1.EndpointConditions{Ready: &ready},
+			expected:   "Ready",
+		},
+		"not ready": {
+			conditions: discoveryv1.EndpointConditions{Ready: &notReady},
+			expected:   "NotReady",
+		},
+		"terminating": {
+			conditions: discoveryv1.EndpointConditions{Ready: &notReady, Terminating: &ready},
+			expected:   "Terminating",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := cmd.FormatEndpointStatus(&discoveryv1.Endpoint{Conditions: tc.conditions})
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+
+	http, dns := int32(80), int32(53)
+	udp := corev1.ProtocolUDP
+	assert.Equal(t, "<none>", cmd.FormatEndpointPorts(&discoveryv1.EndpointSlice{}))
+	assert.Equal(t, "80/TCP,53/UDP", cmd.FormatEndpointPorts(&discoveryv1.EndpointSlice{
+		Ports: []discoveryv1.EndpointPort{{Port: &http}, {Port: &dns, Protocol: &udp}, {}},
+	}))
+}
+
+func TestFormatOwner(t *testing.T) {
+	controller := true
+	tests := map[string]struct {
+		pod      *corev1.Pod
+		expected string
+	}{
+		"no owner": {
+			pod:      &corev1.Pod{},
+			expected: "<none>",
+		},
+		"deployment replica set": {
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"pod-template-hash": "5d59d67564"},
+					OwnerReferences: []metav1.OwnerReference{
+						{Kind: "ReplicaSet", Name: "nginx-5d59d67564", Controller: &controller},
+					},
+				},
+			},
+			expected: "Deployment/nginx",
+		},
+		"standalone replica set": {
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					OwnerReferences: []metav1.OwnerReference{
+						{Kind: "ReplicaSet", Name: "frontend", Controller: &controller},
+					},
+				},
+			},
+			expected: "ReplicaSet/frontend",
+		},
+		"stateful set": {
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					OwnerReferences: []metav1.OwnerReference{
+						{Kind: "StatefulSet", Name: "db", Controller: &controller},
+					},
+				},
+			},
+			expected: "StatefulSet/db",
+		},
+		"controller preferred over other owners": {
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					OwnerReferences: []metav1.OwnerReference{
+						{Kind: "ConfigMap", Name: "settings"},
+						{Kind: "DaemonSet", Name: "fluentd", Controller: &controller},
+					},
+				},
+			},
+			expected: "DaemonSet/fluentd",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := cmd.FormatOwner(tc.pod)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestFormatQoS(t *testing.T) {
+	tests := map[string]struct {
+		pod      *corev1.Pod
+		expected string
+	}{
+		"no qos class": {
+			pod:      &corev1.Pod{},
+			expected: "<unknown>",
+		},
+		"guaranteed": {
+			pod: &corev1.Pod{
+				Status: corev1.PodStatus{QOSClass: corev1.PodQOSGuaranteed},
+			},
+			expected: "Guaranteed",
+		},
+		"best effort": {
+			pod: &corev1.Pod{
+				Status: corev1.PodStatus{QOSClass: corev1.PodQOSBestEffort},
+			},
+			expected: "BestEffort",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := cmd.FormatQoS(tc.pod)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestFormatPriorityClass(t *testing.T) {
+	priority := int32(2000001000)
+
+	tests := map[string]struct {
+		pod      *corev1.Pod
+		expected string
+	}{
+		"no priority class": {
+			pod:      &corev1.Pod{},
+			expected: "<none>",
+		},
+		"priority class without priority": {
+			pod: &corev1.Pod{
+				Spec: corev1.PodSpec{PriorityClassName: "batch-low"},
+			},
+			expected: "batch-low",
+		},
+		"priority class with priority": {
+			pod: &corev1.Pod{
+				Spec: corev1.PodSpec{PriorityClassName: "system-node-critical", Priority: &priority},
+			},
+			expected: "system-node-critical (2000001000)",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := cmd.FormatPriorityClass(tc.pod)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestFormatIPFamily(t *testing.T) {
+	tests := map[string]struct {
+		ip       string
+		expected string
+	}{
+		"ipv4":      {ip: "10.244.1.5", expected: "IPv4"},
+		"ipv6":      {ip: "fd00::5", expected: "IPv6"},
+		"no ip":     {ip: "", expected: "<none>"},
+		"malformed": {ip: "10.244.1", expected: "<unknown>"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, cmd.FormatIPFamily(tc.ip))
+		})
+	}
+}
+
+func TestFormatPodDNS(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}}
+
+	tests := map[string]struct {
+		ip       string
+		domain   string
+		expected string
+	}{
+		"ipv4": {
+			ip:       "10.244.1.5",
+			domain:   "cluster.local",
+			expected: "10-244-1-5.default.pod.cluster.local",
+		},
+		"ipv6": {
+			ip:       "fd00::5",
+			domain:   "cluster.local",
+			expected: "fd00--5.default.pod.cluster.local",
+		},
+		"custom domain": {
+			ip:       "10.244.1.5",
+			domain:   "example.internal",
+			expected: "10-244-1-5.default.pod.example.internal",
+		},
+		"no ip": {
+			ip:       "",
+			domain:   "cluster.local",
+			expected: "<none>",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := cmd.FormatPodDNS(pod, tc.ip, tc.domain)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
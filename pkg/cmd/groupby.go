@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+const (
+	groupByNode      = "node"
+	groupByNamespace = "namespace"
+)
+
+// podIPGroup holds the entries of a --group-by section, in their sorted order.
+type podIPGroup struct {
+	key     string
+	entries []podIPWithPod
+}
+
+// printGroups prints a heading and a table for every node or namespace the entries belong to, keeping the order of
+// the entries within each section. Sections are ordered by name, with the entries without a node last.
+func (o *IPsOptions) printGroups(podIPs []podIPWithPod) error {
+	groups := groupPodIPs(podIPs, o.groupBy)
+
+	columns := o.columnOptions()
+	title := "Node"
+	if o.groupBy == groupByNamespace {
+		// the heading names the namespace of every row
+		columns.showNamespace = false
+		title = "Namespace"
+	}
+
+	for i, group := range groups {
+		if i > 0 {
+			_, _ = fmt.Fprintln(o.Out)
+		}
+		_, _ = fmt.Fprintf(o.Out, "%s: %s\n", title, cmp.Or(group.key, noneValue))
+
+		// the stock table printer prints the header only once, so every section gets its own printer
+		printer, err := createPrinter(o.outputFormat, o.printOptions())
+		if err != nil {
+			return err
+		}
+		if err := printer.PrintObj(makeIPTable(group.entries, columns), o.Out); err != nil {
+			return fmt.Errorf("failed to print object: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// groupPodIPs buckets the entries by the node running them or by their namespace.
+func groupPodIPs(podIPs []podIPWithPod, groupBy string) []podIPGroup {
+	var groups []podIPGroup
+	index := make(map[string]int)
+
+	for _, item := range podIPs {
+		key := item.meta().Namespace
+		if groupBy == groupByNode {
+			key = item.nodeName()
+		}
+
+		i, found := index[key]
+		if !found {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, podIPGroup{key: key})
+		}
+		groups[i].entries = append(groups[i].entries, item)
+	}
+
+	slices.SortFunc(groups, func(a, b podIPGroup) int {
+		// services and pods not scheduled yet have no node and come last
+		if (a.key == "") != (b.key == "") {
+			if a.key == "" {
+				return 1
+			}
+
+			return -1
+		}
+
+		return cmp.Compare(a.key, b.key)
+	})
+
+	return groups
+}
+
+// validateGroupBy rejects unknown --group-by keys and the flags printing something else than tables.
+func (o *IPsOptions) validateGroupBy() error {
+	switch o.groupBy {
+	case "":
+		return nil
+	case groupByNode, groupByNamespace:
+		// valid group keys
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedGroupBy, o.groupBy)
+	}
+
+	switch {
+	case o.outputFormat != tableFormat && o.outputFormat != wideFormat && o.outputFormat != wideIPv6Format &&
+		o.outputFormat != "":
+		return fmt.Errorf("%w: --group-by can only be used with table output", ErrIncompatibleFlags)
+	case o.watch:
+		return fmt.Errorf("%w: --group-by cannot be used with --watch", ErrIncompatibleFlags)
+	case o.count:
+		return fmt.Errorf("%w: --group-by cannot be used with --count", ErrIncompatibleFlags)
+	case o.summary:
+		return fmt.Errorf("%w: --group-by cannot be used with --summary", ErrIncompatibleFlags)
+	case o.diff != "":
+		return fmt.Errorf("%w: --group-by cannot be used with --diff", ErrIncompatibleFlags)
+	case o.showIPsOnly:
+		return fmt.Errorf("%w: --group-by cannot be used with --show-ips-only", ErrIncompatibleFlags)
+	case o.rawIPs:
+		return fmt.Errorf("%w: --group-by cannot be used with --raw-ips", ErrIncompatibleFlags)
+	case o.nodes:
+		return fmt.Errorf("%w: --group-by cannot be used with --nodes", ErrIncompatibleFlags)
+	case o.serverPrint:
+		return fmt.Errorf("%w: --group-by cannot be used with --server-print", ErrIncompatibleFlags)
+	}
+
+	return nil
+}
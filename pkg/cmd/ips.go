@@ -1,25 +1,104 @@
 package cmd
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 )
 
 const (
-	jsonFormat  = "json"
-	yamlFormat  = "yaml"
-	nameFormat  = "name"
-	tableFormat = "table"
-	wideFormat  = "wide"
+	csvFormat               = "csv"
+	tsvFormat               = "tsv"
+	jsonFormat              = "json"
+	yamlFormat              = "yaml"
+	nameFormat              = "name"
+	tableFormat             = "table"
+	wideFormat              = "wide"
+	wideIPv6Format          = "wide-ipv6"
+	customColumnsFormat     = "custom-columns"
+	customColumnsFileFormat = "custom-columns-file"
+	jsonPathFormat          = "jsonpath"
+	goTemplateFormat        = "go-template"
+	goTemplateFileFormat    = "go-template-file"
+	hostsFormat             = "hosts"
+	prometheusFormat        = "prometheus"
+	jsonIPsFormat           = "json-ips"
+	jsonlFormat             = "jsonl"
+)
+
+// defaultHostnameTemplate names hosts entries after the pod and its namespace.
+const defaultHostnameTemplate = "<name>.<namespace>"
+
+const (
+	ipFamilyAll  = "all"
+	ipFamilyIPv4 = "ipv4"
+	ipFamilyIPv6 = "ipv6"
+)
+
+const (
+	ipSourceStatus     = "status"
+	ipSourceAnnotation = "annotation"
+)
+
+// networkStatusAnnotation is set by Multus to the interfaces of a pod attached to several networks, and
+// deprecatedNetworkStatusAnnotation is the spelling still set by older Multus releases.
+const (
+	networkStatusAnnotation           = "k8s.v1.cni.cncf.io/network-status"
+	deprecatedNetworkStatusAnnotation = "k8s.v1.cni.cncf.io/networks-status"
+)
+
+const (
+	colorAuto   = "auto"
+	colorAlways = "always"
+	colorNever  = "never"
+)
+
+const (
+	headerStyleUpper = "upper"
+	headerStyleLower = "lower"
+	headerStyleTitle = "title"
+)
+
+// defaultClusterDomain is the DNS domain Kubernetes clusters use unless configured otherwise.
+const defaultClusterDomain = "cluster.local"
+
+// defaultChunkSize matches the page size kubectl uses when listing large collections.
+const defaultChunkSize = 500
+
+// defaultRetries rides out a few seconds of throttling or API server restarts.
+const defaultRetries = 3
+
+// defaultTimezone prints timestamps in the local time zone, as expected interactively.
+const defaultTimezone = "Local"
+
+const (
+	sortByDefault   = ""
+	sortByName      = "name"
+	sortByNamespace = "namespace"
+	sortByIP        = "ip"
+	sortByAge       = "age"
+	sortByRestarts  = "restarts"
+	sortByStatus    = "status"
 )
 
 var ipsExample = `
@@ -29,23 +108,232 @@ var ipsExample = `
   # list all pod IP addresses in all namespaces
   %[1]s ips --all-namespaces
 
+  # list IP addresses of pods that are running or still pending
+  %[1]s ips --status=Running,Pending
+
+  # list IP addresses of running nginx pods only
+  %[1]s ips --running-only -l app=nginx
+
+  # list pod IP addresses, skipping hostNetwork pods that report their node IP
+  %[1]s ips -A --exclude-host-network
+
+  # audit which workloads use host networking, listing every pod that shares a node IP
+  %[1]s ips -A --host-network-only --no-dedup
+
+  # list pod IP addresses in all namespaces except the control-plane ones
+  %[1]s ips -A --exclude-namespace=kube-system,kube-public
+
   # list pod IP addresses in a specific namespace
   %[1]s ips --namespace=kube-system
 
+  # check in a script whether any nginx pod has an IP, without printing a message when none does
+  %[1]s ips -q -l app=nginx > /dev/null || echo "no nginx pods"
+
+  # exit with a non-zero status when no pod matches, still printing which pods were looked for
+  %[1]s ips -l app=nginx --fail-on-empty
+
+  # list IP addresses of specific pods by name
+  %[1]s ips nginx-5d59d67564-8g7nm redis-0
+
   # filter pods by label selector
   %[1]s ips --selector=app=nginx
 
+  # filter pods matching all of several label selectors
+  %[1]s ips -l app=nginx -l 'env in (prod,staging)'
+
+  # filter pods by a long label selector kept in a file
+  %[1]s ips --selector-file=./selector.txt
+
+  # list IP addresses of the pods backing a service
+  %[1]s ips --service=my-svc
+
+  # also list the manually managed endpoint addresses of a service
+  %[1]s ips --service=my-svc --include-endpoints
+
+  # list IP addresses of the replicas of a known pod
+  %[1]s ips --selector-from-pod=nginx-5d59d67564-8g7nm
+
+  # filter pods by field selector
+  %[1]s ips --field-selector=status.phase=Running,spec.nodeName=worker-1
+
+  # filter pods by a regular expression matched against their names
+  %[1]s ips --pod-name-regex='^worker-\d+$'
+
+  # leave out pods whose names match a regular expression
+  %[1]s ips --exclude-pod-regex='-debug-'
+
+  # show only pod IP addresses within a CIDR range
+  %[1]s ips --cidr=10.244.1.0/24
+
+  # show only IPv6 addresses on a dual-stack cluster
+  %[1]s ips --ip-family=ipv6
+
+  # show the addresses of every network a pod is attached to through Multus
+  %[1]s ips --ip-source=annotation
+
+  # fail instead of printing an empty value when a template key is missing
+  %[1]s ips -o jsonpath='{.items[*].spec.nodeName}' --allow-missing-template-keys=false
+
+  # log the namespace, selectors and pod count behind the results to stderr, warning about malformed pod IPs
+  %[1]s ips -l app=web --verbose
+
+  # leave out pod IPs that are not valid IP addresses
+  %[1]s ips --skip-invalid-ips
+
+  # show a row for every interface of pods using Multus, with its name
+  %[1]s ips --all-interfaces
+
+  # sort pod IP addresses numerically
+  %[1]s ips --sort-by=ip
+
+  # sort pods by restart count
+  %[1]s ips --sort-by=restarts
+
+  # print a section of pod IPs per node, e.g. to investigate node pressure
+  %[1]s ips -A --group-by=node
+
+  # show the newest pods first
+  %[1]s ips --sort-by=age --reverse
+
+  # skip sorting and print the pods in the order the API server returned them
+  %[1]s ips -A --no-sort
+
+  # show only the pods created in the last 10 minutes
+  %[1]s ips -A --since=10m --sort-by=age
+
+  # find long-lived pods created more than 30 days ago
+  %[1]s ips -A --older-than=30d
+
+  # list IP addresses from a snapshot of pods taken earlier
+  kubectl get pods -A -o yaml > pods.yaml
+  %[1]s ips -A -f pods.yaml
+
+  # find the pod that owns an IP address
+  %[1]s ips --lookup=10.244.3.17
+
+  # watch pod IP addresses change during a rollout
+  %[1]s ips --watch --selector=app=nginx
+
+  # give up if the API server does not answer within 10 seconds
+  %[1]s ips --timeout=10s
+
+  # retry up to 5 times while the API server is throttling or restarting
+  %[1]s ips --retries=5
+
+  # list service cluster IPs alongside pod IPs
+  %[1]s ips --include-services
+
+  # generate /etc/hosts entries for pods in all namespaces
+  %[1]s ips -A -o hosts --hostname-template='<name>.<namespace>.pod.cluster.local'
+
+  # print pod IPs as Prometheus metrics for a textfile collector
+  %[1]s ips -A -o prometheus
+
+  # print pod IPs as a plain JSON array for jq
+  %[1]s ips -A -o json-ips
+
+  # compare pod IPs with a snapshot saved before a rollout
+  %[1]s ips -o json-ips > before.json
+  %[1]s ips --diff=before.json
+
+  # print the pod table built by the API server, with the columns of kubectl get pods
+  %[1]s ips --server-print
+
+  # stream pod IPs as JSON Lines, one object per line
+  %[1]s ips -A -o jsonl --watch
+
+  # count the pod IPs in use across all namespaces
+  %[1]s ips -A --count
+
+  # wait up to two minutes for three nginx pods to get an IP, then print them
+  %[1]s ips -l app=nginx --wait --wait-count=3 --wait-timeout=2m
+
+  # list pod IP addresses with how many pods and IPs each namespace has below the table
+  %[1]s ips -A --summary
+
+  # format the pod list with a reusable Go template
+  %[1]s ips --template-file=./ips.tmpl
+
+  # also list pods that have not been assigned an IP yet
+  %[1]s ips --show-pending
+
+  # save pod IPs as CSV for a CI artifact
+  %[1]s ips -A -o csv --output-file=pod-ips.csv
+
+  # show the pod DNS names for a cluster with a custom domain
+  %[1]s ips --show-dns --cluster-domain=example.internal
+
+  # list internal and external node IPs
+  %[1]s ips --nodes
+
   # show only IP addresses without pod names
   %[1]s ips --show-ips-only
 
+  # print the IP addresses as a comma-separated list
+  %[1]s ips --show-ips-only --ips-delimiter=,
+
+  # scan the IP addresses of the selected pods, passed on a single space-separated line
+  nmap $(%[1]s ips -l app=web --raw-ips)
+
   # show wide output with additional columns
   %[1]s ips -o wide
 
+  # show wide output with a row per dual-stack pod and its IPv4 and IPv6 addresses side by side
+  %[1]s ips --wide-with-ipv6
+
+  # show wide output with a row per pod, its primary IP and its secondary IPs in separate columns
+  %[1]s ips -o wide-ipv6
+
+  # print IPv6 addresses in full, zero-padded form for string matching
+  %[1]s ips --show-ips-only --ipv6-expand
+
   # output in JSON format
   %[1]s ips -o json
 
+  # output JSON on a single line for piping
+  %[1]s ips -o json --compact
+
+  # output in CSV format
+  %[1]s ips -o csv
+
+  # output in CSV format with lower-case headers
+  %[1]s ips -o csv --header-style=lower
+
+  # output only the chosen columns
+  %[1]s ips -o custom-columns=NAME:.metadata.name,IP:.status.podIP,NODE:.spec.nodeName
+
+  # output pod IPs using a JSONPath template
+  %[1]s ips -o jsonpath='{range .items[*]}{.status.podIP}{"\n"}{end}'
+
+  # output pod IPs using a Go template
+  %[1]s ips -o go-template='{{range .items}}{{.status.podIP}}{{"\n"}}{{end}}'
+
   # show labels as additional column
   %[1]s ips --show-labels
+
+  # keep rows with many labels on one line by truncating long cells
+  %[1]s ips --show-labels --max-column-width=40
+
+  # show the values of specific labels as additional columns
+  %[1]s ips -L app,version
+
+  # show the value of an annotation as additional column
+  %[1]s ips --annotation-columns=team.example.com/owner
+
+  # show the zone of the node running each pod
+  %[1]s ips -A --show-node-labels=topology.kubernetes.io/zone
+
+  # show declared container ports as additional column
+  %[1]s ips --show-ports
+
+  # show the images of each pod's containers as additional column
+  %[1]s ips -A --show-images
+
+  # show when each pod was created instead of its relative age, e.g. to archive the output
+  %[1]s ips --show-age-timestamp
+
+  # show the creation timestamps in UTC rather than the local time zone
+  %[1]s ips --show-age-timestamp --timezone=UTC
 `
 
 // IPsOptions provides information required to list pod IP addresses.
@@ -53,33 +341,254 @@ type IPsOptions struct {
 	genericiooptions.IOStreams
 
 	configFlags *genericclioptions.ConfigFlags
+	// clientset, when set, is used instead of a client built from configFlags. buildClientset stores the client
+	// it builds here, so that repeated Run calls share it.
+	clientset kubernetes.Interface
+	// contextClientsets, when set, are used instead of clients built for the kubeconfig contexts
+	contextClientsets map[string]kubernetes.Interface
 
 	allNamespaces bool
+	podNames      []string
 	labelSelector string
+	fieldSelector string
+	cidr          string
+	cidrNet       *net.IPNet
+	ipFamily      string
+	ipSource      string
+	allInterfaces bool
+	lookup        string
+	lookupIP      net.IP
+	sortBy        string
+	reverse       bool
+	noSort        bool
 	showIPsOnly   bool
+	rawIPs        bool
 	namespace     string
 	outputFormat  string
 	noHeaders     bool
 	showLabels    bool
+	showPorts     bool
+	watch         bool
+	timeout       time.Duration
+	chunkSize     int64
+
+	// wait lists the pods every waitInterval until waitCount of them have an IP or waitTimeout elapses
+	wait         bool
+	waitCount    int
+	waitTimeout  time.Duration
+	waitInterval time.Duration
+
+	// ipsDelimiter separates the --show-ips-only addresses instead of a newline, with escape sequences such as
+	// \t turned into ipsSeparator by Validate
+	ipsDelimiter string
+	ipsSeparator string
+
+	// timezone names the time zone timestamps are printed in, loaded into location by Validate
+	timezone string
+	location *time.Location
+
+	// retries is how many times a pod listing failing with a transient error is repeated, waiting retryDelay
+	// before the first retry
+	retries    int
+	retryDelay time.Duration
+
+	includeServices bool
+	nodes           bool
+
+	// labelColumns and annotationColumns are the label and annotation keys shown as a column each
+	labelColumns      []string
+	annotationColumns []string
+	// nodeLabelColumns are the keys of the labels of the pods' nodes shown as a column each
+	nodeLabelColumns []string
+	// dualStack shows a row per pod with its IPv4 and IPv6 addresses side by side
+	dualStack bool
+	// expandIPv6 prints IPv6 addresses in full, zero-padded form
+	expandIPv6 bool
+
+	hostnameTemplate  string
+	excludeNamespaces []string
+	statuses          []string
+	runningOnly       bool
+	// podNameRegex keeps only the pods whose name matches it, compiled into podNamePattern by Validate
+	podNameRegex   string
+	podNamePattern *regexp.Regexp
+	// excludePodRegex leaves out the pods whose name matches it, compiled into excludePodPattern by Validate
+	excludePodRegex   string
+	excludePodPattern *regexp.Regexp
+
+	excludeHostNetwork  bool
+	hostNetworkOnly     bool
+	count               bool
+	summary             bool
+	outputFile          string
+	color               string
+	noDedup             bool
+	showPending         bool
+	showDNS             bool
+	clusterDomain       string
+	templateFile        string
+	maxPods             int
+	containers          bool
+	includeInitRestarts bool
+	showAgeTimestamp    bool
+
+	contexts []string
+	// namespaceFromContext is set when no namespace was requested, so every context lists its own default
+	namespaceFromContext bool
+
+	// service, when set, selects the pods backing this service instead of --selector
+	service          string
+	includeEndpoints bool
+	// selectorFromPod, when set, selects the pods labeled like this one instead of --selector
+	selectorFromPod string
+	// selectorFile, when set, names a file holding the label selector, read into labelSelector by Complete
+	selectorFile string
+	// labelSelectors holds every --selector flag, joined into labelSelector by Complete
+	labelSelectors []string
+
+	// quiet replaces the "No pods found" message with a silent ErrNoPodsFound
+	quiet bool
+	// failOnEmpty returns ErrNoPodsFound after the "No pods found" message
+	failOnEmpty bool
+	// verbose logs the effective query and how many pods and IPs it found to stderr, warning about malformed pod IPs
+	verbose bool
+	// skipInvalidIPs leaves out the pod IPs that do not parse as IP addresses
+	skipInvalidIPs bool
+
+	headerStyle string
+	// compact prints JSON output on a single line
+	compact bool
+	// allowMissingKeys prints missing template keys empty instead of failing, like kubectl
+	allowMissingKeys bool
+
+	// since and olderThan keep only the pods younger and older than these, turned into the createdAfter and
+	// createdBefore bounds when Run starts
+	since         time.Duration
+	olderThan     time.Duration
+	createdAfter  time.Time
+	createdBefore time.Time
+
+	// filename, when set, is a file of pods, or "-" for stdin, read in place of listing the cluster
+	filename string
+	// diff, when set, is a json-ips or jsonl snapshot the current pod IPs are compared with
+	diff string
+	// serverPrint prints the pod table of the API server in place of the pod IPs
+	serverPrint bool
+	// alignedColumns prints tables with the built-in aligned printer instead of the stock table printer, behind
+	// the hidden --aligned-columns flag until it replaces it
+	alignedColumns bool
+	// maxColumnWidth truncates the longer table cells to this many characters, zero keeping them whole
+	maxColumnWidth int
+	// showImages adds an IMAGES column with the images of the pod's containers
+	showImages bool
+	// groupBy prints a section per node or namespace instead of a single table
+	groupBy string
 }
 
 // NewIPsOptions provides an instance of IPsOptions with default values.
 func NewIPsOptions(streams genericiooptions.IOStreams) *IPsOptions {
 	return &IPsOptions{
-		configFlags: genericclioptions.NewConfigFlags(true),
-		IOStreams:   streams,
+		configFlags:   genericclioptions.NewConfigFlags(true),
+		IOStreams:     streams,
+		ipFamily:      ipFamilyAll,
+		ipSource:      ipSourceStatus,
+		chunkSize:     defaultChunkSize,
+		color:         colorAuto,
+		clusterDomain: defaultClusterDomain,
+		retries:       defaultRetries,
+		retryDelay:    defaultRetryDelay,
+		ipsSeparator:  "\n",
+		timezone:      defaultTimezone,
+		waitCount:     1,
+		waitTimeout:   defaultWaitTimeout,
+		waitInterval:  defaultWaitInterval,
+		headerStyle:   headerStyleUpper,
+
+		allowMissingKeys: true,
 	}
 }
 
-// ErrUnsupportedFormat is returned when an unsupported output format is specified.
-var ErrUnsupportedFormat = errors.New("unsupported output format")
+var (
+	// ErrUnsupportedFormat is returned when an unsupported output format is specified.
+	ErrUnsupportedFormat = errors.New("unsupported output format")
+	// ErrUnsupportedIPFamily is returned when an unsupported IP family is specified.
+	ErrUnsupportedIPFamily = errors.New("unsupported IP family")
+	// ErrUnsupportedIPSource is returned when an unsupported IP source is specified.
+	ErrUnsupportedIPSource = errors.New("unsupported IP source")
+	// ErrUnsupportedSortKey is returned when an unsupported sort key is specified.
+	ErrUnsupportedSortKey = errors.New("unsupported sort key")
+	// ErrUnsupportedGroupBy is returned when --group-by names neither node nor namespace.
+	ErrUnsupportedGroupBy = errors.New("unsupported group key")
+	// ErrUnsupportedColorMode is returned when an unsupported color mode is specified.
+	ErrUnsupportedColorMode = errors.New("unsupported color mode")
+	// ErrUnsupportedHeaderStyle is returned when an unsupported header style is specified.
+	ErrUnsupportedHeaderStyle = errors.New("unsupported header style")
+	// ErrInvalidIP is returned when an IP address cannot be parsed.
+	ErrInvalidIP = errors.New("invalid IP address")
+	// ErrNoPodWithIP is returned when no pod owns the IP address passed to --lookup.
+	ErrNoPodWithIP = errors.New("no pod found with IP")
+	// ErrNoPodsFound is returned with --quiet or --fail-on-empty when nothing matches, so that scripts can branch
+	// on the exit status.
+	ErrNoPodsFound = errors.New("no pods found")
+	// ErrNoNodesFound is returned with --nodes and --quiet or --fail-on-empty when no node matches.
+	ErrNoNodesFound = errors.New("no nodes found")
+	// ErrInvalidTimeout is returned when a negative timeout is specified.
+	ErrInvalidTimeout = errors.New("timeout must not be negative")
+	// ErrInvalidChunkSize is returned when a negative chunk size is specified.
+	ErrInvalidChunkSize = errors.New("chunk size must not be negative")
+	// ErrInvalidSince is returned when a negative --since duration is specified.
+	ErrInvalidSince = errors.New("since must not be negative")
+	// ErrInvalidOlderThan is returned when a negative --older-than duration is specified.
+	ErrInvalidOlderThan = errors.New("older than must not be negative")
+	// ErrInvalidRetries is returned when a negative number of retries is specified.
+	ErrInvalidRetries = errors.New("retries must not be negative")
+	// ErrInvalidMaxPods is returned when a negative --max-pods limit is specified.
+	ErrInvalidMaxPods = errors.New("max pods must not be negative")
+	// ErrInvalidMaxColumnWidth is returned when a negative --max-column-width is specified.
+	ErrInvalidMaxColumnWidth = errors.New("max column width must not be negative")
+	// ErrUnknownTimezone is returned when --timezone does not name a known time zone.
+	ErrUnknownTimezone = errors.New("unknown time zone")
+	// ErrInvalidPodNameRegex is returned when --pod-name-regex or --exclude-pod-regex is not a valid regular
+	// expression.
+	ErrInvalidPodNameRegex = errors.New("invalid pod name regex")
+	// ErrInvalidDelimiter is returned when --ips-delimiter holds a malformed escape sequence.
+	ErrInvalidDelimiter = errors.New("invalid IPs delimiter")
+	// ErrServerPrintUnsupported is returned when the API server does not answer --server-print with a table.
+	ErrServerPrintUnsupported = errors.New("server does not support server-side printing")
+	// ErrInvalidWaitCount is returned when --wait-count is not positive.
+	ErrInvalidWaitCount = errors.New("wait count must be positive")
+	// ErrInvalidWaitTimeout is returned when a negative --wait-timeout is specified.
+	ErrInvalidWaitTimeout = errors.New("wait timeout must not be negative")
+	// ErrWaitTimeout is returned when fewer pods than --wait-count have an IP once --wait-timeout elapses.
+	ErrWaitTimeout = errors.New("timed out waiting for pod IPs")
+	// ErrContextNotFound is returned when a context passed to --contexts is not in the kubeconfig.
+	ErrContextNotFound = errors.New("context not found in kubeconfig")
+	// ErrRequestTimeout is returned when an API request does not complete within the configured timeout.
+	ErrRequestTimeout = errors.New("request timed out")
+	// ErrNoKubeconfig is returned when neither a kubeconfig nor an in-cluster configuration is available.
+	ErrNoKubeconfig = errors.New("no kubeconfig found")
+	// ErrUnauthorized is returned when the API server rejects the credentials of an API request.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrForbidden is returned when the user is not allowed to perform an API request, e.g. list pods.
+	ErrForbidden = errors.New("forbidden")
+	// ErrIncompatibleFlags is returned when flags that cannot be combined are specified together.
+	ErrIncompatibleFlags = errors.New("incompatible flags")
+	// ErrNamesWithAllNamespaces is returned when pod names are combined with --all-namespaces.
+	ErrNamesWithAllNamespaces = errors.New("a pod cannot be retrieved by name across all namespaces")
+	// ErrNamesWithSelector is returned when pod names are combined with a label or field selector.
+	ErrNamesWithSelector = errors.New("pod names cannot be provided when a selector is specified")
+	// ErrServiceWithoutSelector is returned when the service passed to --service does not select any pods.
+	ErrServiceWithoutSelector = errors.New("service has no pod selector")
+	// ErrPodWithoutLabels is returned when the pod passed to --selector-from-pod has no labels to select by.
+	ErrPodWithoutLabels = errors.New("pod has no labels to select by")
+)
 
 // NewCmdIPs provides a cobra command wrapping IPsOptions.
 func NewCmdIPs(streams genericiooptions.IOStreams) *cobra.Command {
 	o := NewIPsOptions(streams)
 
 	cmd := &cobra.Command{
-		Use:          "ips [flags]",
+		Use:          "ips [NAME ...] [flags]",
 		Short:        "List IP addresses from Kubernetes pods",
 		Example:      fmt.Sprintf(ipsExample, "kubectl"),
 		SilenceUsage: true,
@@ -93,7 +602,12 @@ func NewCmdIPs(streams genericiooptions.IOStreams) *cobra.Command {
 			if err := o.Validate(); err != nil {
 				return err
 			}
-			if err := o.Run(); err != nil {
+			if err := o.Run(c.Context()); err != nil {
+				// the exit status is the whole report in quiet mode, and follows the message with --fail-on-empty
+				if errors.Is(err, ErrNoPodsFound) || errors.Is(err, ErrNoNodesFound) {
+					c.SilenceErrors = true
+				}
+
 				return err
 			}
 
@@ -103,27 +617,235 @@ func NewCmdIPs(streams genericiooptions.IOStreams) *cobra.Command {
 
 	cmd.Flags().BoolVarP(&o.allNamespaces, "all-namespaces", "A", false,
 		"If true, list IP addresses from pods in all namespaces")
-	cmd.Flags().StringVarP(&o.labelSelector, "selector", "l", "",
-		"Selector (label query) to filter on, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2)")
+	cmd.Flags().StringSliceVar(&o.excludeNamespaces, "exclude-namespace", nil,
+		"Namespaces to leave out when listing all namespaces. Can be repeated or comma-separated")
+	cmd.Flags().StringArrayVarP(&o.labelSelectors, "selector", "l", nil,
+		"Selector (label query) to filter on, supports '=', '==', '!=', 'in', 'notin', 'key' and '!key'."+
+			"(e.g. -l key1=value1,key2=value2 or -l 'env in (prod,staging),!canary'). "+
+			"Can be repeated, listing the pods matching all of them")
+	cmd.Flags().StringVar(&o.selectorFile, "selector-file", "",
+		"If present, read the selector (label query) from this file, avoiding shell quoting of long set-based selectors")
+	cmd.Flags().StringVar(&o.service, "service", "",
+		"List IP addresses of the pods selected by this service, using its selector in place of --selector")
+	cmd.Flags().BoolVar(&o.includeEndpoints, "include-endpoints", false,
+		"If true, also list the addresses of the --service endpoint slices not backed by a pod, adding a TYPE column")
+	cmd.Flags().StringVar(&o.selectorFromPod, "selector-from-pod", "",
+		"List IP addresses of the pods with the same labels as this pod, such as the other replicas of its workload, "+
+			"ignoring labels like pod-template-hash that differ between replicas")
+	cmd.Flags().StringVar(&o.fieldSelector, "field-selector", "",
+		"Selector (field query) to filter on, supports '=', '==', and '!='.(e.g. --field-selector key1=value1,key2=value2)")
+	cmd.Flags().StringVarP(&o.filename, "filename", "f", "",
+		"Read the pods from this YAML or JSON file, or '-' for stdin, instead of the cluster, "+
+			"e.g. the output of 'kubectl get pods -o yaml'")
+	cmd.Flags().StringVar(&o.diff, "diff", "",
+		"If present, compare the pod IPs with this snapshot saved with -o json-ips or -o jsonl, printing the pods "+
+			"whose IPs were added (+), removed (-) or changed (~)")
+	cmd.Flags().BoolVar(&o.serverPrint, "server-print", false,
+		"If true, print the pod table built by the API server, with the columns of kubectl get pods, "+
+			"instead of the pod IPs")
+	cmd.Flags().StringSliceVar(&o.statuses, "status", nil,
+		"Only show pods whose displayed STATUS matches one of these values, ignoring case "+
+			"(e.g. Running,Pending,Terminating). Can be repeated or comma-separated")
+	cmd.Flags().StringVar(&o.podNameRegex, "pod-name-regex", "",
+		"If present, only show pods whose name matches this regular expression (e.g. 'worker-\\d+')")
+	cmd.Flags().StringVar(&o.excludePodRegex, "exclude-pod-regex", "",
+		"If present, skip pods whose name matches this regular expression (e.g. '-debug-'), "+
+			"applied after --pod-name-regex")
+	cmd.Flags().BoolVar(&o.runningOnly, "running-only", false,
+		"If true, only show pods whose status is Running. Shortcut for --status=Running")
+	cmd.Flags().BoolVar(&o.excludeHostNetwork, "exclude-host-network", false,
+		"If true, skip pods using the host network, whose pod IP is the node IP")
+	cmd.Flags().BoolVar(&o.hostNetworkOnly, "host-network-only", false,
+		"If true, only show pods using the host network")
+	cmd.Flags().BoolVar(&o.noDedup, "no-dedup", false,
+		"If true, list an IP for every pod reporting it instead of only the first one, e.g. for hostNetwork pods")
+	cmd.Flags().Var(newDurationValue(&o.since), "since",
+		"Only show pods created within this duration, e.g. 10m, 2h or 7d. Zero shows pods of any age")
+	cmd.Flags().Var(newDurationValue(&o.olderThan), "older-than",
+		"Only show pods created longer ago than this duration, e.g. 12h or 30d. Zero shows pods of any age")
+	cmd.Flags().BoolVar(&o.skipInvalidIPs, "skip-invalid-ips", false,
+		"If true, leave out pod IPs that are not valid IP addresses, which a faulty CNI plugin may report")
+	cmd.Flags().BoolVar(&o.showPending, "show-pending", false,
+		"If true, also list pods that have no IP address yet, such as pending ones, with <none> in the IP column")
+	cmd.Flags().StringVar(&o.cidr, "cidr", "",
+		"If present, only show IP addresses within the given CIDR range (e.g. 10.244.1.0/24 or fd00::/64)")
+	cmd.Flags().StringVar(&o.ipFamily, "ip-family", ipFamilyAll,
+		"Only show IP addresses of the given family. One of: (ipv4, ipv6, all)")
+	cmd.Flags().StringVar(&o.ipSource, "ip-source", ipSourceStatus,
+		"Where to read pod IP addresses from. One of: (status, annotation). Annotation lists the addresses of every "+
+			"interface in the Multus network-status annotation, falling back to the status for pods without it")
+	cmd.Flags().BoolVar(&o.allInterfaces, "all-interfaces", false,
+		"If true, list the addresses of every interface of pods using Multus with an INTERFACE column. "+
+			"Implies --ip-source=annotation")
+	cmd.Flags().StringVar(&o.lookup, "lookup", "",
+		"If present, find the pod owning the given IP address. Searches all namespaces unless --namespace is set")
+	cmd.Flags().StringVar(&o.sortBy, "sort-by", sortByDefault,
+		"If non-empty, sort output by the given key instead of namespace and name. "+
+			"One of: (name, namespace, ip, age, restarts, status)")
+	cmd.Flags().BoolVar(&o.reverse, "reverse", false, "If true, reverse the sort order of the output")
+	cmd.Flags().StringVar(&o.groupBy, "group-by", "",
+		"If non-empty, print a heading and a table per node or namespace, keeping the sort order within each. "+
+			"One of: (node, namespace)")
+	cmd.Flags().BoolVar(&o.noSort, "no-sort", false,
+		"If true, keep the order the API server listed the pods in instead of sorting them, e.g. for large clusters")
 	cmd.Flags().BoolVar(&o.showIPsOnly, "show-ips-only", false, "If true, show only IP addresses without pod names")
+	cmd.Flags().BoolVar(&o.rawIPs, "raw-ips", false,
+		"If true, print only the IP addresses on a single space-separated line, e.g. to pass them to nmap")
+	cmd.Flags().StringVar(&o.ipsDelimiter, "ips-delimiter", "",
+		"Separator between the addresses printed by --show-ips-only instead of a newline, e.g. ',' for a "+
+			"comma-separated list. Escape sequences such as \\t are interpreted")
 	cmd.Flags().StringVarP(&o.outputFormat, "output", "o", "table",
-		"Output format. One of: (table, wide, json, yaml, name)")
+		"Output format. One of: (table, wide, wide-ipv6, json, yaml, name, csv, tsv, custom-columns=..., "+
+			"custom-columns-file=..., jsonpath=..., go-template=..., go-template-file=..., hosts, prometheus, json-ips, "+
+			"jsonl)")
+	cmd.Flags().StringVar(&o.outputFile, "output-file", "",
+		"If present, write the output to this file instead of stdout. Messages such as 'No pods found' still go to stderr")
+	cmd.Flags().StringVar(&o.templateFile, "template-file", "",
+		"If present, print the pod list with the Go template in this file. Shortcut for -o go-template-file=...")
+	cmd.Flags().BoolVar(&o.allowMissingKeys, "allow-missing-template-keys", true,
+		"If true, ignore any errors in templates when a field or map key is missing in the template. "+
+			"Only applies to golang and jsonpath output formats")
+	cmd.Flags().BoolVarP(&o.quiet, "quiet", "q", false,
+		"If true, print nothing when no pods are found and exit with a non-zero status instead")
+	cmd.Flags().BoolVar(&o.failOnEmpty, "fail-on-empty", false,
+		"If true, exit with a non-zero status when no pods are found, still printing the 'No pods found' message")
+	cmd.Flags().BoolVarP(&o.verbose, "verbose", "v", false,
+		"If true, log the namespace, selectors and output format used and how many pods and IPs were found to stderr, "+
+			"warning about malformed pod IPs")
+	cmd.Flags().StringVar(&o.headerStyle, "header-style", headerStyleUpper,
+		"Case of the column headers in table, CSV and TSV output. One of: upper|lower|title")
+	cmd.Flags().BoolVar(&o.compact, "compact", false,
+		"If true, print json and json-ips output on a single line instead of indented")
 	cmd.Flags().BoolVar(&o.noHeaders, "no-headers", false,
-		"When using the default or custom output format, don't print headers")
+		"When using the default, custom, CSV, TSV or prometheus output format, don't print headers")
+	cmd.Flags().BoolVar(&o.dualStack, "wide-with-ipv6", false,
+		"If true, show one row per pod with its addresses in IPV4 and IPV6 columns, using wide output unless -o is given")
+	cmd.Flags().BoolVar(&o.expandIPv6, "ipv6-expand", false,
+		"If true, print IPv6 addresses in full with zero-padded groups, e.g. 0000:0000:0000:0000:0000:0000:0000:0001")
+	cmd.Flags().BoolVar(&o.containers, "containers", false,
+		"If true, print a row for every container of a pod, with its name and its own ready state and restarts")
+	cmd.Flags().BoolVar(&o.includeInitRestarts, "include-init-restarts", false,
+		"If true, count init container restarts in the RESTARTS column and when sorting by restarts")
+	cmd.Flags().BoolVar(&o.showDNS, "show-dns", false,
+		"When printing, show the DNS name of each pod IP as an additional column")
+	cmd.Flags().StringVar(&o.clusterDomain, "cluster-domain", defaultClusterDomain,
+		"The cluster DNS domain used for the DNS column")
+	cmd.Flags().StringVar(&o.color, "color", colorAuto,
+		"Color the STATUS column of table output. One of: (auto, always, never). Auto colors only when writing to a terminal")
+	cmd.Flags().BoolVar(&o.alignedColumns, "aligned-columns", false,
+		"Experimental: if true, print tables with the built-in printer padding columns to their widest cell")
+	_ = cmd.Flags().MarkHidden("aligned-columns")
 	cmd.Flags().BoolVar(&o.showLabels, "show-labels", false, "When printing, show all labels as the last column")
+	cmd.Flags().IntVar(&o.maxColumnWidth, "max-column-width", 0,
+		"Truncate the cells of table output longer than this many characters, ending them with '...'. "+
+			"Zero means no truncation")
+	cmd.Flags().StringSliceVarP(&o.labelColumns, "label-columns", "L", nil,
+		"Labels to show as a column each, named after the upper-cased key. "+
+			"Can be repeated or comma-separated (e.g. -L app,version)")
+	cmd.Flags().StringSliceVar(&o.annotationColumns, "annotation-columns", nil,
+		"Annotations to show as a column each, named after the upper-cased key. "+
+			"Can be repeated or comma-separated (e.g. --annotation-columns=team.example.com/owner)")
+	cmd.Flags().StringSliceVar(&o.nodeLabelColumns, "show-node-labels", nil,
+		"Labels of the node running each pod to show as a column each, named after the upper-cased key. "+
+			"Can be repeated or comma-separated (e.g. --show-node-labels=topology.kubernetes.io/zone)")
+	cmd.Flags().BoolVar(&o.showPorts, "show-ports", false,
+		"When printing, show the declared container ports as an additional column")
+	cmd.Flags().BoolVar(&o.showImages, "show-images", false,
+		"When printing, show the images of the pod's containers as an additional column")
+	cmd.Flags().BoolVar(&o.showAgeTimestamp, "show-age-timestamp", false,
+		"If true, print the RFC3339 creation timestamp in the AGE column instead of the relative age, "+
+			"e.g. for archived output")
+	cmd.Flags().StringVar(&o.timezone, "timezone", defaultTimezone,
+		"Time zone of the printed timestamps. One of: Local, UTC, or an IANA name such as Europe/Berlin")
+	cmd.Flags().StringVar(&o.hostnameTemplate, "hostname-template", defaultHostnameTemplate,
+		"Hostname written for each IP with -o hosts. <name> and <namespace> are replaced with the pod's values")
+	cmd.Flags().BoolVar(&o.includeServices, "include-services", false,
+		"If true, also list the cluster and external IP addresses of services, adding a TYPE column")
+	cmd.Flags().BoolVar(&o.count, "count", false,
+		"If true, print a summary of how many IPs, pods and namespaces matched instead of listing them")
+	cmd.Flags().BoolVar(&o.summary, "summary", false,
+		"If true, print how many pods and IPs each namespace has after the table, e.g. 'kube-system: 12 pods, 14 IPs'")
+	cmd.Flags().BoolVar(&o.nodes, "nodes", false,
+		"If true, list the internal and external IP addresses of nodes instead of pods")
+	cmd.Flags().BoolVarP(&o.watch, "watch", "w", false,
+		"After listing the requested pods, watch for changes and print a row for every added, modified or deleted pod")
+	cmd.Flags().BoolVar(&o.wait, "wait", false,
+		"If true, list the pods again every few seconds until --wait-count of them have an IP, then print them")
+	cmd.Flags().IntVar(&o.waitCount, "wait-count", 1,
+		"Number of matching pods that must have an IP before --wait prints them")
+	cmd.Flags().DurationVar(&o.waitTimeout, "wait-timeout", defaultWaitTimeout,
+		"The length of time --wait polls before failing with a non-zero exit status. Zero means wait forever")
+	cmd.Flags().DurationVar(&o.timeout, "timeout", 0,
+		"The length of time to wait for the API server to list pods before giving up (e.g. 30s). Zero means no timeout")
+	cmd.Flags().IntVar(&o.retries, "retries", defaultRetries,
+		"Number of times to retry listing pods after a transient error such as throttling, with exponential backoff")
+	cmd.Flags().Int64Var(&o.chunkSize, "chunk-size", defaultChunkSize,
+		"Return large lists in chunks rather than all at once. Pass 0 to disable")
+	cmd.Flags().StringSliceVar(&o.contexts, "contexts", nil,
+		"List pods from each of these kubeconfig contexts, adding a CLUSTER column when more than one is given. "+
+			"Can be repeated or comma-separated")
+	cmd.Flags().IntVar(&o.maxPods, "max-pods", 0,
+		"If greater than zero, print at most this many rows after sorting, noting the total on stderr. Zero means no limit")
 	o.configFlags.AddFlags(cmd.Flags())
 
+	_ = cmd.RegisterFlagCompletionFunc("namespace", o.completeNamespaces)
+	_ = cmd.RegisterFlagCompletionFunc("output", completeOutputFormats)
+	_ = cmd.RegisterFlagCompletionFunc("selector", o.completeLabelSelector)
+
 	return cmd
 }
 
 // Complete sets all information required for listing pod IPs.
-func (o *IPsOptions) Complete(cmd *cobra.Command, _ []string) error {
+func (o *IPsOptions) Complete(cmd *cobra.Command, args []string) error {
+	o.podNames = args
+
 	var err error
 	o.namespace, err = cmd.Flags().GetString("namespace")
 	if err != nil {
 		return fmt.Errorf("failed to get namespace flag: %w", err)
 	}
 
+	if o.templateFile != "" {
+		if cmd.Flags().Changed("output") {
+			return fmt.Errorf("%w: --template-file cannot be used with --output", ErrIncompatibleFlags)
+		}
+		o.outputFormat = goTemplateFileFormat + "=" + o.templateFile
+	}
+
+	if cmd.Flags().Changed("selector") {
+		selectors, err := cmd.Flags().GetStringArray("selector")
+		if err != nil {
+			return fmt.Errorf("failed to get selector flag: %w", err)
+		}
+		o.labelSelector = joinSelectors(selectors)
+	}
+
+	if o.selectorFile != "" {
+		if cmd.Flags().Changed("selector") {
+			return fmt.Errorf("%w: --selector-file cannot be used with --selector", ErrIncompatibleFlags)
+		}
+		data, err := os.ReadFile(o.selectorFile)
+		if err != nil {
+			return fmt.Errorf("failed to read selector file: %w", err)
+		}
+		o.labelSelector = strings.TrimSpace(string(data))
+	}
+
+	if o.dualStack && !cmd.Flags().Changed("output") && o.outputFormat == tableFormat {
+		o.outputFormat = wideFormat
+	}
+
+	// a lookup searches the whole cluster unless a namespace was requested explicitly,
+	// and prints the full row including the node
+	if o.lookup != "" {
+		if !cmd.Flags().Changed("namespace") {
+			o.allNamespaces = true
+		}
+		if o.outputFormat == tableFormat || o.outputFormat == "" {
+			o.outputFormat = wideFormat
+		}
+	}
+
 	if o.allNamespaces {
 		o.namespace = ""
 	}
@@ -139,6 +861,7 @@ func (o *IPsOptions) Complete(cmd *cobra.Command, _ []string) error {
 				return fmt.Errorf("failed to get current namespace: %w", err)
 			}
 			o.namespace = currentNS
+			o.namespaceFromContext = true
 		}
 	}
 
@@ -147,11 +870,167 @@ func (o *IPsOptions) Complete(cmd *cobra.Command, _ []string) error {
 
 // Validate ensures that all required arguments and flag values are provided.
 func (o *IPsOptions) Validate() error {
-	switch o.outputFormat {
-	case tableFormat, wideFormat, jsonFormat, yamlFormat, nameFormat, "":
-		// valid formats
+	if len(o.podNames) > 0 {
+		if o.allNamespaces {
+			return ErrNamesWithAllNamespaces
+		}
+		if o.labelSelector != "" || o.fieldSelector != "" {
+			return ErrNamesWithSelector
+		}
+	}
+
+	if _, err := createPrinter(o.outputFormat, o.printOptions()); err != nil {
+		return err
+	}
+
+	if err := validateIPFamily(o.ipFamily); err != nil {
+		return err
+	}
+
+	if err := validateIPSource(o.ipSource); err != nil {
+		return err
+	}
+
+	if err := validateSortBy(o.sortBy); err != nil {
+		return err
+	}
+
+	if o.noSort && o.sortBy != sortByDefault {
+		return fmt.Errorf("%w: --no-sort cannot be used with --sort-by", ErrIncompatibleFlags)
+	}
+
+	switch o.color {
+	case colorAuto, colorAlways, colorNever:
+		// valid color modes
 	default:
-		return ErrUnsupportedFormat
+		return fmt.Errorf("%w: %q", ErrUnsupportedColorMode, o.color)
+	}
+
+	switch o.headerStyle {
+	case headerStyleUpper, headerStyleLower, headerStyleTitle:
+		// valid header styles
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedHeaderStyle, o.headerStyle)
+	}
+
+	location, err := time.LoadLocation(o.timezone)
+	if err != nil {
+		return fmt.Errorf("%w: %q", ErrUnknownTimezone, o.timezone)
+	}
+	o.location = location
+
+	if o.podNameRegex != "" {
+		pattern, err := regexp.Compile(o.podNameRegex)
+		if err != nil {
+			return fmt.Errorf("%w %q: %w", ErrInvalidPodNameRegex, o.podNameRegex, err)
+		}
+		o.podNamePattern = pattern
+	}
+
+	if o.excludePodRegex != "" {
+		pattern, err := regexp.Compile(o.excludePodRegex)
+		if err != nil {
+			return fmt.Errorf("%w %q: %w", ErrInvalidPodNameRegex, o.excludePodRegex, err)
+		}
+		o.excludePodPattern = pattern
+	}
+
+	if o.compact && o.outputFormat != jsonFormat && o.outputFormat != jsonIPsFormat {
+		return fmt.Errorf("%w: --compact can only be used with -o json or -o json-ips", ErrIncompatibleFlags)
+	}
+
+	if err := validateLabelSelector(o.labelSelector); err != nil {
+		return err
+	}
+
+	if o.fieldSelector != "" {
+		if _, err := fields.ParseSelector(o.fieldSelector); err != nil {
+			return fmt.Errorf("invalid field selector %q: %w", o.fieldSelector, err)
+		}
+	}
+
+	if o.excludeHostNetwork && o.hostNetworkOnly {
+		return fmt.Errorf("%w: --exclude-host-network cannot be used with --host-network-only", ErrIncompatibleFlags)
+	}
+
+	if o.includeServices && isTemplateFormat(o.outputFormat) {
+		// the templates run on the list of pods, which has no room for the services
+		return fmt.Errorf("%w: --include-services cannot be used with -o jsonpath or go-template", ErrIncompatibleFlags)
+	}
+
+	if err := o.validateWatch(); err != nil {
+		return err
+	}
+
+	if err := o.validateGroupBy(); err != nil {
+		return err
+	}
+
+	if err := o.validateSummary(); err != nil {
+		return err
+	}
+
+	if err := o.validateDiff(); err != nil {
+		return err
+	}
+
+	if err := o.validateServerPrint(); err != nil {
+		return err
+	}
+
+	if err := o.validateRawIPs(); err != nil {
+		return err
+	}
+
+	if err := o.validateIPsDelimiter(); err != nil {
+		return err
+	}
+
+	if err := o.validateDualStack(); err != nil {
+		return err
+	}
+
+	if err := o.validateService(); err != nil {
+		return err
+	}
+
+	if err := o.validateSelectorFromPod(); err != nil {
+		return err
+	}
+
+	if err := o.validateContexts(); err != nil {
+		return err
+	}
+
+	if err := o.validateFilename(); err != nil {
+		return err
+	}
+
+	if err := o.validateNodes(); err != nil {
+		return err
+	}
+
+	if err := o.validateLimits(); err != nil {
+		return err
+	}
+
+	if err := o.validateWait(); err != nil {
+		return err
+	}
+
+	if o.lookup != "" {
+		o.lookupIP = net.ParseIP(o.lookup)
+		if o.lookupIP == nil {
+			return fmt.Errorf("%w: %q", ErrInvalidIP, o.lookup)
+		}
+	}
+
+	if o.cidr != "" {
+		_, cidrNet, err := net.ParseCIDR(o.cidr)
+		if err != nil {
+			return fmt.Errorf("invalid CIDR %q: %w", o.cidr, err)
+		}
+		o.cidrNet = cidrNet
 	}
 
 	return nil
@@ -162,35 +1041,355 @@ func (o *IPsOptions) SetOutputFormat(format string) {
 	o.outputFormat = format
 }
 
+// validateLimits rejects negative durations, sizes and counts, and an --older-than bound not shorter than --since.
+func (o *IPsOptions) validateLimits() error {
+	switch {
+	case o.timeout < 0:
+		return fmt.Errorf("%w: %s", ErrInvalidTimeout, o.timeout)
+	case o.chunkSize < 0:
+		return fmt.Errorf("%w: %d", ErrInvalidChunkSize, o.chunkSize)
+	case o.maxPods < 0:
+		return fmt.Errorf("%w: %d", ErrInvalidMaxPods, o.maxPods)
+	case o.maxColumnWidth < 0:
+		return fmt.Errorf("%w: %d", ErrInvalidMaxColumnWidth, o.maxColumnWidth)
+	case o.retries < 0:
+		return fmt.Errorf("%w: %d", ErrInvalidRetries, o.retries)
+	case o.since < 0:
+		return fmt.Errorf("%w: %s", ErrInvalidSince, o.since)
+	case o.olderThan < 0:
+		return fmt.Errorf("%w: %s", ErrInvalidOlderThan, o.olderThan)
+	case o.since > 0 && o.olderThan >= o.since:
+		return fmt.Errorf("%w: --older-than must be shorter than --since", ErrIncompatibleFlags)
+	}
+
+	return nil
+}
+
+// validateNodes rejects the pod-specific flags that have no meaning when listing node addresses.
+func (o *IPsOptions) validateNodes() error {
+	if !o.nodes {
+		return nil
+	}
+
+	switch {
+	case len(o.podNames) > 0:
+		return fmt.Errorf("%w: pod names cannot be used with --nodes", ErrIncompatibleFlags)
+	case o.watch:
+		return fmt.Errorf("%w: --watch cannot be used with --nodes", ErrIncompatibleFlags)
+	case o.includeServices:
+		return fmt.Errorf("%w: --include-services cannot be used with --nodes", ErrIncompatibleFlags)
+	case o.lookup != "":
+		return fmt.Errorf("%w: --lookup cannot be used with --nodes", ErrIncompatibleFlags)
+	case o.count:
+		return fmt.Errorf("%w: --count cannot be used with --nodes", ErrIncompatibleFlags)
+	case o.maxPods > 0:
+		return fmt.Errorf("%w: --max-pods cannot be used with --nodes", ErrIncompatibleFlags)
+	case len(o.contexts) > 0:
+		return fmt.Errorf("%w: --contexts cannot be used with --nodes", ErrIncompatibleFlags)
+	case o.containers:
+		return fmt.Errorf("%w: --containers cannot be used with --nodes", ErrIncompatibleFlags)
+	case o.service != "":
+		return fmt.Errorf("%w: --service cannot be used with --nodes", ErrIncompatibleFlags)
+	case o.dualStack:
+		return fmt.Errorf("%w: --wide-with-ipv6 cannot be used with --nodes", ErrIncompatibleFlags)
+	case o.since > 0:
+		return fmt.Errorf("%w: --since cannot be used with --nodes", ErrIncompatibleFlags)
+	case o.olderThan > 0:
+		return fmt.Errorf("%w: --older-than cannot be used with --nodes", ErrIncompatibleFlags)
+	case o.expandIPv6:
+		return fmt.Errorf("%w: --ipv6-expand cannot be used with --nodes", ErrIncompatibleFlags)
+	case len(o.nodeLabelColumns) > 0:
+		return fmt.Errorf("%w: --show-node-labels cannot be used with --nodes", ErrIncompatibleFlags)
+	case o.allInterfaces:
+		return fmt.Errorf("%w: --all-interfaces cannot be used with --nodes", ErrIncompatibleFlags)
+	}
+
+	return nil
+}
+
+// validateService rejects the flags that select pods in another way than the service passed to --service.
+func (o *IPsOptions) validateService() error {
+	if o.service == "" {
+		if o.includeEndpoints {
+			return fmt.Errorf("%w: --include-endpoints can only be used with --service", ErrIncompatibleFlags)
+		}
+
+		return nil
+	}
+
+	switch {
+	case len(o.podNames) > 0:
+		return fmt.Errorf("%w: pod names cannot be used with --service", ErrIncompatibleFlags)
+	case o.labelSelector != "":
+		return fmt.Errorf("%w: --selector cannot be used with --service", ErrIncompatibleFlags)
+	case o.allNamespaces:
+		return fmt.Errorf("%w: --all-namespaces cannot be used with --service", ErrIncompatibleFlags)
+	case o.includeEndpoints && o.watch:
+		return fmt.Errorf("%w: --include-endpoints cannot be used with --watch", ErrIncompatibleFlags)
+	}
+
+	return nil
+}
+
+func (o *IPsOptions) validateWait() error {
+	if !o.wait {
+		return nil
+	}
+
+	switch {
+	case o.waitCount < 1:
+		return fmt.Errorf("%w: %d", ErrInvalidWaitCount, o.waitCount)
+	case o.waitTimeout < 0:
+		return fmt.Errorf("%w: %s", ErrInvalidWaitTimeout, o.waitTimeout)
+	case o.watch:
+		return fmt.Errorf("%w: --wait cannot be used with --watch", ErrIncompatibleFlags)
+	case o.nodes:
+		return fmt.Errorf("%w: --wait cannot be used with --nodes", ErrIncompatibleFlags)
+	case o.filename != "":
+		return fmt.Errorf("%w: --wait cannot be used with --filename", ErrIncompatibleFlags)
+	}
+
+	return nil
+}
+
+// validateIPsDelimiter interprets the escape sequences of --ips-delimiter, which only applies to --show-ips-only.
+func (o *IPsOptions) validateIPsDelimiter() error {
+	if o.ipsDelimiter == "" {
+		return nil
+	}
+	if !o.showIPsOnly {
+		return fmt.Errorf("%w: --ips-delimiter can only be used with --show-ips-only", ErrIncompatibleFlags)
+	}
+
+	separator, err := strconv.Unquote(`"` + o.ipsDelimiter + `"`)
+	if err != nil {
+		return fmt.Errorf("%w: %q", ErrInvalidDelimiter, o.ipsDelimiter)
+	}
+	o.ipsSeparator = separator
+
+	return nil
+}
+
+func (o *IPsOptions) validateRawIPs() error {
+	if !o.rawIPs {
+		return nil
+	}
+
+	switch {
+	case o.showIPsOnly:
+		return fmt.Errorf("%w: --raw-ips cannot be used with --show-ips-only", ErrIncompatibleFlags)
+	case o.dualStack:
+		return fmt.Errorf("%w: --raw-ips cannot be used with --wide-with-ipv6", ErrIncompatibleFlags)
+	case o.count:
+		return fmt.Errorf("%w: --raw-ips cannot be used with --count", ErrIncompatibleFlags)
+	case o.summary:
+		return fmt.Errorf("%w: --raw-ips cannot be used with --summary", ErrIncompatibleFlags)
+	case o.watch:
+		return fmt.Errorf("%w: --raw-ips cannot be used with --watch", ErrIncompatibleFlags)
+	case o.nodes:
+		return fmt.Errorf("%w: --raw-ips cannot be used with --nodes", ErrIncompatibleFlags)
+	}
+
+	return nil
+}
+
+// validateDualStack rejects the flags and output formats that need a row per IP, which --wide-with-ipv6 merges
+// into a row per pod.
+func (o *IPsOptions) validateDualStack() error {
+	if !o.dualStack {
+		return nil
+	}
+
+	// these formats read the IP column, which the dual-stack layout replaces with IPV4 and IPV6
+	ipColumnFormats := []string{hostsFormat, prometheusFormat, jsonIPsFormat, jsonlFormat}
+
+	switch {
+	case o.showIPsOnly:
+		return fmt.Errorf("%w: --wide-with-ipv6 cannot be used with --show-ips-only", ErrIncompatibleFlags)
+	case slices.Contains(ipColumnFormats, o.outputFormat):
+		return fmt.Errorf("%w: --wide-with-ipv6 cannot be used with -o %s", ErrIncompatibleFlags, o.outputFormat)
+	case o.outputFormat == wideIPv6Format:
+		return fmt.Errorf("%w: -o wide-ipv6 cannot be used with --wide-with-ipv6", ErrIncompatibleFlags)
+	case o.allInterfaces:
+		return fmt.Errorf("%w: --all-interfaces cannot be used with --wide-with-ipv6", ErrIncompatibleFlags)
+	}
+
+	return nil
+}
+
+// validateSummary only allows the --summary footer below a table listing, where it cannot break parsable output.
+func (o *IPsOptions) validateSummary() error {
+	if !o.summary {
+		return nil
+	}
+
+	switch {
+	case o.outputFormat != tableFormat && o.outputFormat != wideFormat && o.outputFormat != "":
+		return fmt.Errorf("%w: --summary can only be used with table or wide output", ErrIncompatibleFlags)
+	case o.count:
+		return fmt.Errorf("%w: --summary cannot be used with --count", ErrIncompatibleFlags)
+	case o.watch:
+		return fmt.Errorf("%w: --summary cannot be used with --watch", ErrIncompatibleFlags)
+	case o.showIPsOnly:
+		return fmt.Errorf("%w: --summary cannot be used with --show-ips-only", ErrIncompatibleFlags)
+	case o.nodes:
+		return fmt.Errorf("%w: --summary cannot be used with --nodes", ErrIncompatibleFlags)
+	}
+
+	return nil
+}
+
+// validateSelectorFromPod rejects the flags that select pods in another way than the pod passed to
+// --selector-from-pod, and those listing pods in other places than the namespace of the pod.
+func (o *IPsOptions) validateSelectorFromPod() error {
+	if o.selectorFromPod == "" {
+		return nil
+	}
+
+	switch {
+	case len(o.podNames) > 0:
+		return fmt.Errorf("%w: pod names cannot be used with --selector-from-pod", ErrIncompatibleFlags)
+	case o.labelSelector != "":
+		return fmt.Errorf("%w: --selector cannot be used with --selector-from-pod", ErrIncompatibleFlags)
+	case o.service != "":
+		return fmt.Errorf("%w: --service cannot be used with --selector-from-pod", ErrIncompatibleFlags)
+	case o.allNamespaces:
+		return fmt.Errorf("%w: --all-namespaces cannot be used with --selector-from-pod", ErrIncompatibleFlags)
+	case len(o.contexts) > 0:
+		return fmt.Errorf("%w: --contexts cannot be used with --selector-from-pod", ErrIncompatibleFlags)
+	case o.filename != "":
+		return fmt.Errorf("%w: --filename cannot be used with --selector-from-pod", ErrIncompatibleFlags)
+	case o.nodes:
+		return fmt.Errorf("%w: --nodes cannot be used with --selector-from-pod", ErrIncompatibleFlags)
+	}
+
+	return nil
+}
+
+func (o *IPsOptions) validateContexts() error {
+	if len(o.contexts) == 0 {
+		return nil
+	}
+
+	switch {
+	case o.watch:
+		return fmt.Errorf("%w: --watch cannot be used with --contexts", ErrIncompatibleFlags)
+	case o.configFlags.Context != nil && *o.configFlags.Context != "":
+		return fmt.Errorf("%w: --context cannot be used with --contexts", ErrIncompatibleFlags)
+	}
+
+	return nil
+}
+
 // Run lists IP addresses from pods based on the provided options.
-func (o *IPsOptions) Run() error {
-	pods, err := o.getPods()
+// Canceling the context aborts in-flight API requests.
+func (o *IPsOptions) Run(ctx context.Context) (err error) {
+	now := time.Now()
+	if o.since > 0 {
+		o.createdAfter = now.Add(-o.since)
+	}
+	if o.olderThan > 0 {
+		o.createdBefore = now.Add(-o.olderThan)
+	}
+
+	// every context gets its own client when listing several of them, and a pods file needs none
+	var clientset kubernetes.Interface
+	if len(o.contexts) == 0 && o.filename == "" {
+		clientset, err = o.buildClientset()
+		if err != nil {
+			return err
+		}
+	}
+
+	if o.outputFile != "" {
+		output := &lazyFile{path: o.outputFile}
+		defer func() {
+			// a successful run replaces the file even when no pods matched, a failed one leaves it alone
+			if err == nil {
+				err = output.open()
+			}
+			if closeErr := output.Close(); closeErr != nil && err == nil {
+				err = closeErr
+			}
+		}()
+		o.Out = output
+	}
+
+	o.logQuery()
+
+	if o.serverPrint {
+		return o.runServerPrint(ctx, clientset)
+	}
+
+	if o.nodes {
+		return o.runNodes(ctx, clientset)
+	}
+
+	if o.watch {
+		return o.runWatch(ctx, clientset)
+	}
+
+	var podIPs []podIPWithPod
+	if o.wait {
+		podIPs, err = o.waitForPodIPs(ctx, clientset)
+	} else {
+		podIPs, err = o.collectIPs(ctx, clientset)
+	}
 	if err != nil {
 		return err
 	}
+	podIPs = orderPodIPs(podIPs, o.ipListOptions())
+
+	if o.diff != "" {
+		return o.printDiff(podIPs)
+	}
+
+	// the script-oriented outputs below report an empty result like the table does
+	if len(podIPs) == 0 {
+		return o.printNoPodsFound()
+	}
+
+	if o.count {
+		_, _ = fmt.Fprintln(o.Out, summarizeIPs(podIPs))
+
+		return nil
+	}
+
+	// the limit applies to the rows printed, which pairing merges per pod
+	if o.dualStack {
+		podIPs = pairIPFamilies(podIPs)
+	}
+	if o.outputFormat == wideIPv6Format {
+		podIPs = groupSecondaryIPs(podIPs)
+	}
+	podIPs = o.limitPodIPs(podIPs)
 
 	// Handle legacy --show-ips-only flag
 	if o.showIPsOnly {
-		printer := &ipOnlyPrinter{}
+		printIPs(podIPs, o.Out, o.expandIPv6, o.ipsSeparator)
 
-		return printer.PrintObj(pods, o.Out)
+		return nil
 	}
 
-	// Check if we have any pods
-	if len(pods.Items) == 0 {
-		return o.printNoPodsFound()
+	if o.rawIPs {
+		printIPs(podIPs, o.Out, o.expandIPv6, " ")
+
+		return nil
+	}
+
+	if o.groupBy != "" && len(podIPs) > 0 {
+		return o.printGroups(podIPs)
 	}
 
 	// Generate table for new output formats
-	wide := o.outputFormat == wideFormat
-	table := generateTable(pods, o.allNamespaces, wide, o.showLabels)
+	table := makeIPTable(podIPs, o.columnOptions())
 
 	if len(table.Rows) == 0 {
 		return o.printNoPodsFound()
 	}
 
 	// Create and use appropriate printer
-	printer, err := createPrinter(o.outputFormat, o.noHeaders, o.allNamespaces)
+	printer, err := createPrinter(o.outputFormat, o.printOptions())
 	if err != nil {
 		return err
 	}
@@ -199,10 +1398,220 @@ func (o *IPsOptions) Run() error {
 		return fmt.Errorf("failed to print object: %w", err)
 	}
 
+	if o.summary {
+		_, _ = fmt.Fprintln(o.Out)
+		for _, line := range namespaceSummaries(podIPs) {
+			_, _ = fmt.Fprintln(o.Out, line)
+		}
+	}
+
 	return nil
 }
 
-func (o *IPsOptions) getPods() (*corev1.PodList, error) {
+// collectIPs collects the matching IP addresses from every context passed to --contexts, or from the cluster.
+func (o *IPsOptions) collectIPs(ctx context.Context, clientset kubernetes.Interface) ([]podIPWithPod, error) {
+	if len(o.contexts) > 0 {
+		return o.extractContextIPs(ctx)
+	}
+
+	return o.extractClusterIPs(ctx, clientset, o.namespace)
+}
+
+// logQuery writes the effective namespace, selectors and output format to stderr for --verbose.
+func (o *IPsOptions) logQuery() {
+	if !o.nodes {
+		o.logf("Namespace: %s", namespaceDescription(o.namespace))
+	}
+	o.logf("Label selector: %s", cmp.Or(o.labelSelector, noneValue))
+	o.logf("Field selector: %s", cmp.Or(o.fieldSelector, noneValue))
+	o.logf("Output format: %s", cmp.Or(o.outputFormat, tableFormat))
+}
+
+// logf writes a diagnostic line to stderr when --verbose is set, leaving stdout to the results.
+func (o *IPsOptions) logf(format string, args ...any) {
+	if !o.verbose {
+		return
+	}
+
+	_, _ = fmt.Fprintf(o.ErrOut, format+"\n", args...)
+}
+
+// namespaceDescription names the namespace in messages, describing the empty one as all namespaces.
+func namespaceDescription(namespace string) string {
+	return cmp.Or(namespace, "all namespaces")
+}
+
+// lazyFile is the --output-file, created or truncated on the first write so that nothing is overwritten before
+// the pods were listed.
+type lazyFile struct {
+	path string
+	file *os.File
+}
+
+func (f *lazyFile) Write(data []byte) (int, error) {
+	if err := f.open(); err != nil {
+		return 0, err
+	}
+
+	n, err := f.file.Write(data)
+	if err != nil {
+		return n, fmt.Errorf("failed to write output file: %w", err)
+	}
+
+	return n, nil
+}
+
+// open creates or truncates the file unless it is open already.
+func (f *lazyFile) open() error {
+	if f.file != nil {
+		return nil
+	}
+
+	file, err := os.Create(f.path)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	f.file = file
+
+	return nil
+}
+
+// Close closes the file if it was created.
+func (f *lazyFile) Close() error {
+	if f.file == nil {
+		return nil
+	}
+
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("failed to close output file: %w", err)
+	}
+
+	return nil
+}
+
+// limitPodIPs caps the sorted entries at --max-pods, noting on stderr how many were left out.
+func (o *IPsOptions) limitPodIPs(podIPs []podIPWithPod) []podIPWithPod {
+	if o.maxPods == 0 || len(podIPs) <= o.maxPods {
+		return podIPs
+	}
+
+	_, _ = fmt.Fprintf(o.ErrOut, "... (showing %d of %d)\n", o.maxPods, len(podIPs))
+
+	return podIPs[:o.maxPods]
+}
+
+func (o *IPsOptions) printOptions() printOptions {
+	return printOptions{
+		noHeaders:        o.noHeaders,
+		showNamespace:    o.allNamespaces,
+		hostnameTemplate: o.hostnameTemplate,
+		headerStyle:      o.headerStyle,
+		compact:          o.compact,
+		strictTemplates:  !o.allowMissingKeys,
+		// streamed watch rows share one tab writer with the initial output, which colored tables can't join
+		color: o.useColor() && !o.watch,
+		// aligned tables size their columns for every print, so streamed watch rows would not line up with them
+		alignedColumns: o.alignedColumns && !o.watch,
+		maxColumnWidth: o.maxColumnWidth,
+	}
+}
+
+// useColor reports whether the status column is colored, detecting a terminal on the output in auto mode.
+func (o *IPsOptions) useColor() bool {
+	switch o.color {
+	case colorAlways:
+		return true
+	case colorAuto:
+		file, ok := o.Out.(*os.File)
+		if !ok {
+			return false
+		}
+		info, err := file.Stat()
+
+		return err == nil && info.Mode()&os.ModeCharDevice != 0
+	default:
+		return false
+	}
+}
+
+// listOptions translates the command line flags into the options of the pod IP listing.
+func (o *IPsOptions) listOptions() ListOptions {
+	opts := ListOptions{
+		Namespace:           o.namespace,
+		LabelSelector:       o.labelSelector,
+		FieldSelector:       o.fieldSelector,
+		ChunkSize:           o.chunkSize,
+		CIDR:                o.cidrNet,
+		IPFamily:            o.ipFamily,
+		IPSource:            o.ipSource,
+		Address:             o.lookupIP,
+		Statuses:            o.statuses,
+		PodNamePattern:      o.podNamePattern,
+		ExcludePodPattern:   o.excludePodPattern,
+		ExcludeHostNetwork:  o.excludeHostNetwork,
+		HostNetworkOnly:     o.hostNetworkOnly,
+		KeepDuplicates:      o.noDedup,
+		IncludePending:      o.showPending,
+		SkipInvalidIPs:      o.skipInvalidIPs,
+		CreatedAfter:        o.createdAfter,
+		CreatedBefore:       o.createdBefore,
+		SortBy:              o.sortBy,
+		Reverse:             o.reverse,
+		NoSort:              o.noSort,
+		IncludeInitRestarts: o.includeInitRestarts,
+	}
+	// excluding namespaces only makes sense when listing across all of them
+	if o.allNamespaces {
+		opts.ExcludeNamespaces = o.excludeNamespaces
+	}
+	// only the network-status annotation tells the interfaces of a pod apart
+	if o.allInterfaces {
+		opts.IPSource = ipSourceAnnotation
+	}
+
+	return opts
+}
+
+func (o *IPsOptions) ipListOptions() ipListOptions {
+	return o.listOptions().ipListOptions()
+}
+
+func (o *IPsOptions) columnOptions() columnOptions {
+	return columnOptions{
+		showCluster:   len(o.contexts) > 1,
+		showNamespace: o.allNamespaces,
+		showType:      o.includeServices || o.includeEndpoints,
+		containers:    o.containers && o.outputFormat != nameFormat, // a row per container repeats the name
+		wide:          o.outputFormat == wideFormat || o.outputFormat == wideIPv6Format,
+		showLabels:    o.showLabels,
+		showPorts:     o.showPorts,
+		showImages:    o.showImages,
+		showDNS:       o.showDNS,
+		clusterDomain: strings.TrimSuffix(o.clusterDomain, "."),
+
+		includeInitRestarts: o.includeInitRestarts,
+		labelColumns:        o.labelColumns,
+		annotationColumns:   o.annotationColumns,
+		nodeLabelColumns:    o.nodeLabelColumns,
+		dualStack:           o.dualStack,
+		expandIPv6:          o.expandIPv6,
+		showInterface:       o.allInterfaces,
+		secondaryIPs:        o.outputFormat == wideIPv6Format,
+		ageTimestamp:        o.showAgeTimestamp,
+		location:            o.location,
+	}
+}
+
+// buildClientset returns the client of the current context, building it from configFlags on the first call only.
+func (o *IPsOptions) buildClientset() (kubernetes.Interface, error) {
+	if o.clientset != nil {
+		return o.clientset, nil
+	}
+
+	if err := o.checkKubeconfig(); err != nil {
+		return nil, err
+	}
+
 	config, err := o.configFlags.ToRESTConfig()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get REST config: %w", err)
@@ -212,37 +1621,272 @@ func (o *IPsOptions) getPods() (*corev1.PodList, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create kubernetes client: %w", err)
 	}
+	o.clientset = clientset
 
-	ctx := context.Background()
-	listOptions := metav1.ListOptions{}
-	if o.labelSelector != "" {
-		listOptions.LabelSelector = o.labelSelector
+	return clientset, nil
+}
+
+// checkKubeconfig returns ErrNoKubeconfig when no kubeconfig defines a cluster, no server was passed and the
+// command does not run in a pod, rather than letting the client fall back to http://localhost:8080.
+func (o *IPsOptions) checkKubeconfig() error {
+	if o.configFlags.APIServer != nil && *o.configFlags.APIServer != "" || os.Getenv("KUBERNETES_MASTER") != "" {
+		return nil
+	}
+
+	// errors loading the kubeconfig are reported when building the REST config
+	rawConfig, err := o.configFlags.ToRawKubeConfigLoader().RawConfig()
+	if err != nil || len(rawConfig.Clusters) > 0 {
+		return nil
+	}
+	if _, err := rest.InClusterConfig(); !errors.Is(err, rest.ErrNotInCluster) {
+		return nil
 	}
 
+	return fmt.Errorf("%w: set --kubeconfig or the KUBECONFIG environment variable", ErrNoKubeconfig)
+}
+
+// extractClusterIPs lists the pods, and services and endpoint slices if requested, in the namespace of one cluster
+// and collects their matching IP addresses in listing order.
+func (o *IPsOptions) extractClusterIPs(
+	ctx context.Context, clientset kubernetes.Interface, namespace string,
+) ([]podIPWithPod, error) {
 	var pods *corev1.PodList
-	if o.allNamespaces {
-		pods, err = clientset.CoreV1().Pods("").List(ctx, listOptions)
+	var err error
+	if o.filename != "" {
+		pods, err = o.readPods(namespace)
 	} else {
-		pods, err = clientset.CoreV1().Pods(o.namespace).List(ctx, listOptions)
+		pods, err = o.getPods(ctx, clientset, namespace)
+	}
+	// a service without a selector is backed by its endpoints alone
+	if o.includeEndpoints && errors.Is(err, ErrServiceWithoutSelector) {
+		pods, err = &corev1.PodList{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	o.dropNotRunningPods(pods)
+
+	var services *corev1.ServiceList
+	if o.includeServices {
+		services, err = o.getServices(ctx, clientset, namespace)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var endpointSlices *discoveryv1.EndpointSliceList
+	if o.includeEndpoints {
+		endpointSlices, err = o.getEndpointSlices(ctx, clientset, namespace)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sources := ipSources{pods: pods, services: services, endpointSlices: endpointSlices}
+	filter := o.ipListOptions().filter
+	for _, item := range malformedPodIPs(pods, filter.source) {
+		o.logf("Warning: pod %s/%s has a malformed IP %q", item.pod.Namespace, item.pod.Name, item.ip)
+	}
+	podIPs := extractIPs(sources, filter)
+	o.logf("Found %s in %s, %s matching", pluralize(len(pods.Items), "pod"), namespaceDescription(namespace),
+		pluralize(summarizeIPs(podIPs).ips, "IP"))
+
+	if len(o.nodeLabelColumns) > 0 {
+		if err := o.joinNodes(ctx, clientset, podIPs); err != nil {
+			return nil, err
+		}
+	}
+
+	return podIPs, nil
+}
+
+func (o *IPsOptions) getPods(
+	ctx context.Context, clientset kubernetes.Interface, namespace string,
+) (*corev1.PodList, error) {
+	var pods *corev1.PodList
+	err := o.withTimeout(ctx, func(ctx context.Context) error {
+		return o.withRetries(ctx, func() error {
+			var err error
+			if len(o.podNames) > 0 {
+				pods, err = o.getNamedPods(ctx, clientset, namespace)
+
+				return o.explainForbidden(err, "get", "pods", namespace)
+			}
+
+			listOptions, err := o.podListOptionsIn(ctx, clientset, namespace)
+			if err != nil {
+				return err
+			}
+			pods, err = listPods(ctx, clientset, namespace, listOptions, o.chunkSize)
+
+			return o.explainForbidden(err, "list", "pods", namespace)
+		})
+	})
+	if err != nil {
+		return nil, err
 	}
+
+	return pods, nil
+}
+
+// getServices lists services matching the label selector, as field selectors only apply to pods.
+func (o *IPsOptions) getServices(
+	ctx context.Context, clientset kubernetes.Interface, namespace string,
+) (*corev1.ServiceList, error) {
+	var services *corev1.ServiceList
+	err := o.withTimeout(ctx, func(ctx context.Context) error {
+		var err error
+		services, err = clientset.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: o.labelSelector,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list services: %w", classifyAPIError(err))
+		}
+
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list pods: %w", err)
+		return nil, err
+	}
+
+	return services, nil
+}
+
+// explainForbidden replaces the message of a request forbidden by RBAC with the permission it lacked and how to
+// get it, keeping the error of the API server wrapped and logging it with --verbose. Other errors are returned as is.
+func (o *IPsOptions) explainForbidden(err error, verb, resource, namespace string) error {
+	if !errors.Is(err, ErrForbidden) {
+		return err
+	}
+
+	o.logf("Error: %v", err)
+
+	return &forbiddenError{verb: verb, resource: resource, namespace: namespace, err: err}
+}
+
+// withTimeout runs the request bounded by --timeout, reporting an exceeded deadline with the configured value.
+func (o *IPsOptions) withTimeout(ctx context.Context, request func(ctx context.Context) error) error {
+	if o.timeout <= 0 {
+		return request(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, o.timeout)
+	defer cancel()
+
+	err := request(ctx)
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w after %s: %w", ErrRequestTimeout, o.timeout, err)
+	}
+
+	return err
+}
+
+func (o *IPsOptions) podListOptions() metav1.ListOptions {
+	return o.listOptions().podListOptions()
+}
+
+func (o *IPsOptions) getNamedPods(
+	ctx context.Context, clientset kubernetes.Interface, namespace string,
+) (*corev1.PodList, error) {
+	pods := &corev1.PodList{}
+	var missing []string
+
+	for _, name := range o.podNames {
+		pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			missing = append(missing, name)
+
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pod %q: %w", name, classifyAPIError(err))
+		}
+		pods.Items = append(pods.Items, *pod)
+	}
+
+	// report missing pods only when others were found, otherwise the no pods message covers them
+	if len(pods.Items) > 0 {
+		for _, name := range missing {
+			_, _ = fmt.Fprintf(o.ErrOut, "pod %q not found in namespace %q\n", name, namespace)
+		}
 	}
 
 	return pods, nil
 }
 
 func (o *IPsOptions) printNoPodsFound() error {
-	namespace := o.namespace
-	if namespace == "" {
-		namespace = "all namespaces"
+	if o.quiet {
+		return ErrNoPodsFound
 	}
+
+	if o.lookup != "" {
+		return fmt.Errorf("%w %s", ErrNoPodWithIP, o.lookup)
+	}
+
+	namespace := namespaceDescription(o.namespace)
 	selectorInfo := ""
 	if o.labelSelector != "" {
 		selector, _ := labels.Parse(o.labelSelector)
 		selectorInfo = fmt.Sprintf(" matching selector %q", selector.String())
 	}
-	_, _ = fmt.Fprintf(o.Out, "No pods found in %s%s\n", namespace, selectorInfo)
+	if o.fieldSelector != "" {
+		selector, _ := fields.ParseSelector(o.fieldSelector)
+		selectorInfo += fmt.Sprintf(" matching field selector %q", selector.String())
+	}
+	if o.service != "" {
+		selectorInfo += fmt.Sprintf(" selected by service %q", o.service)
+	}
+	if o.selectorFromPod != "" {
+		selectorInfo += fmt.Sprintf(" labeled like pod %q", o.selectorFromPod)
+	}
+	if len(o.podNames) > 0 {
+		selectorInfo += " named " + quoteNames(o.podNames)
+	}
+	if len(o.statuses) > 0 {
+		selectorInfo += " with status " + quoteNames(o.statuses)
+	}
+	if o.podNameRegex != "" {
+		selectorInfo += fmt.Sprintf(" with name matching %q", o.podNameRegex)
+	}
+	if o.excludePodRegex != "" {
+		selectorInfo += fmt.Sprintf(" with name not matching %q", o.excludePodRegex)
+	}
+	if o.runningOnly {
+		selectorInfo += " that are running"
+	}
+	if o.since > 0 {
+		selectorInfo += " created in the last " + formatDuration(o.since)
+	}
+	if o.olderThan > 0 {
+		selectorInfo += " created more than " + formatDuration(o.olderThan) + " ago"
+	}
+	_, _ = fmt.Fprintf(o.ErrOut, "No pods found in %s%s\n", namespace, selectorInfo)
+
+	if o.failOnEmpty {
+		return ErrNoPodsFound
+	}
 
 	return nil
 }
+
+// dropNotRunningPods removes the pods whose status is not Running when --running-only is set, keeping the running
+// pods held back by a readiness gate.
+func (o *IPsOptions) dropNotRunningPods(pods *corev1.PodList) {
+	if !o.runningOnly {
+		return
+	}
+
+	pods.Items = slices.DeleteFunc(pods.Items, func(pod corev1.Pod) bool {
+		return podStatus(&pod) != string(corev1.PodRunning)
+	})
+}
+
+func quoteNames(names []string) string {
+	quoted := make([]string, 0, len(names))
+	for _, name := range names {
+		quoted = append(quoted, fmt.Sprintf("%q", name))
+	}
+
+	return strings.Join(quoted, ", ")
+}
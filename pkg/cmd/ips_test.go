@@ -2,12 +2,22 @@ package cmd_test
 
 import (
 	"bytes"
+	"context"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/andreygrechin/kubectl-ips/pkg/cmd"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
 )
 
 func TestNewIPsOptions(t *testing.T) {
@@ -23,7 +33,7 @@ func TestNewCmdIPs(t *testing.T) {
 	command := cmd.NewCmdIPs(streams)
 
 	assert.NotNil(t, command)
-	assert.Equal(t, "ips [flags]", command.Use)
+	assert.Equal(t, "ips [NAME ...] [flags]", command.Use)
 	assert.Contains(t, command.Short, "List IP addresses from Kubernetes pods")
 }
 
@@ -86,38 +96,1331 @@ func TestIPsOptionsComplete(t *testing.T) {
 	}
 }
 
+func TestIPsOptionsComplete_contextNamespace(t *testing.T) {
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(kubeconfig, []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: dev
+  cluster:
+    server: https://dev.example.com
+contexts:
+- name: team-a
+  context:
+    cluster: dev
+    namespace: team-a
+- name: plain
+  context:
+    cluster: dev
+current-context: team-a
+`), 0o600))
+
+	tests := map[string]struct {
+		args     []string
+		expected string
+	}{
+		"namespace of the current context": {
+			expected: "api\n",
+		},
+		"namespace flag": {
+			args:     []string{"--namespace=default"},
+			expected: "web\n",
+		},
+		"context without namespace": {
+			args:     []string{"--context=plain"},
+			expected: "web\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, _ := genericiooptions.NewTestIOStreams()
+			options := cmd.NewIPsOptions(streams)
+			command := &cobra.Command{}
+			options.AddConfigFlags(command.Flags())
+			require.NoError(t, command.Flags().Parse(append(tc.args, "--kubeconfig="+kubeconfig)))
+
+			require.NoError(t, options.Complete(command, nil))
+			options.SetClientset(fake.NewClientset(
+				newRunTestPod("team-a", "api", corev1.PodRunning, nil, "10.244.2.4"),
+				newRunTestPod("default", "web", corev1.PodRunning, nil, "10.244.1.5"),
+			))
+			options.SetOutputFormat("name")
+			require.NoError(t, options.Validate())
+
+			require.NoError(t, options.Run(context.Background()))
+			assert.Equal(t, tc.expected, out.String())
+		})
+	}
+}
+
 func TestIPsOptionsValidate(t *testing.T) {
 	tests := map[string]struct {
-		outputFormat string
-		expectError  bool
+		outputFormat string
+		expectError  bool
+	}{
+		"valid table format": {
+			outputFormat: "table",
+			expectError:  false,
+		},
+		"valid wide format": {
+			outputFormat: "wide",
+			expectError:  false,
+		},
+		"valid json format": {
+			outputFormat: "json",
+			expectError:  false,
+		},
+		"valid yaml format": {
+			outputFormat: "yaml",
+			expectError:  false,
+		},
+		"valid name format": {
+			outputFormat: "name",
+			expectError:  false,
+		},
+		"valid csv format": {
+			outputFormat: "csv",
+			expectError:  false,
+		},
+		"valid hosts format": {
+			outputFormat: "hosts",
+			expectError:  false,
+		},
+		"valid prometheus format": {
+			outputFormat: "prometheus",
+			expectError:  false,
+		},
+		"valid tsv format": {
+			outputFormat: "tsv",
+			expectError:  false,
+		},
+		"valid custom-columns format": {
+			outputFormat: "custom-columns=NAME:.metadata.name,IP:.status.podIP",
+			expectError:  false,
+		},
+		"malformed custom-columns format": {
+			outputFormat: "custom-columns=NAME",
+			expectError:  true,
+		},
+		"valid jsonpath format": {
+			outputFormat: "jsonpath={.items[*].status.podIP}",
+			expectError:  false,
+		},
+		"malformed jsonpath format": {
+			outputFormat: "jsonpath={.items[*].status.podIP",
+			expectError:  true,
+		},
+		"valid go-template format": {
+			outputFormat: "go-template={{range .items}}{{.status.podIP}}{{end}}",
+			expectError:  false,
+		},
+		"malformed go-template format": {
+			outputFormat: "go-template={{range .items}}",
+			expectError:  true,
+		},
+		"empty format": {
+			outputFormat: "",
+			expectError:  false,
+		},
+		"invalid format": {
+			outputFormat: "invalid",
+			expectError:  true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsDiscard()
+			options := cmd.NewIPsOptions(streams)
+			options.SetOutputFormat(tc.outputFormat)
+
+			err := options.Validate()
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestIPsOptionsValidateFieldSelector(t *testing.T) {
+	tests := map[string]struct {
+		fieldSelector string
+		expectError   bool
+	}{
+		"empty selector": {
+			fieldSelector: "",
+			expectError:   false,
+		},
+		"phase selector": {
+			fieldSelector: "status.phase=Running",
+			expectError:   false,
+		},
+		"combined selectors": {
+			fieldSelector: "status.phase!=Failed,spec.nodeName==worker-1",
+			expectError:   false,
+		},
+		"missing operator": {
+			fieldSelector: "status.phase",
+			expectError:   true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsDiscard()
+			options := cmd.NewIPsOptions(streams)
+			options.SetFieldSelector(tc.fieldSelector)
+
+			err := options.Validate()
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestIPsOptionsValidateLabelSelector(t *testing.T) {
+	tests := map[string]struct {
+		labelSelector string
+		expectError   bool
+	}{
+		"empty selector": {
+			labelSelector: "",
+			expectError:   false,
+		},
+		"negation": {
+			labelSelector: "app!=web",
+			expectError:   false,
+		},
+		"set-based selectors": {
+			labelSelector: "env in (prod,staging),tier notin (cache),release,!canary",
+			expectError:   false,
+		},
+		"unclosed set": {
+			labelSelector: "env in (prod,staging",
+			expectError:   true,
+		},
+		"missing values": {
+			labelSelector: "env in",
+			expectError:   true,
+		},
+		"invalid key": {
+			labelSelector: "-app=web",
+			expectError:   true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsDiscard()
+			options := cmd.NewIPsOptions(streams)
+			options.SetLabelSelector(tc.labelSelector)
+
+			err := options.Validate()
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestIPsOptionsValidateCIDR(t *testing.T) {
+	tests := map[string]struct {
+		cidr        string
+		expectError bool
+	}{
+		"empty cidr": {
+			cidr:        "",
+			expectError: false,
+		},
+		"ipv4 cidr": {
+			cidr:        "10.244.1.0/24",
+			expectError: false,
+		},
+		"ipv6 cidr": {
+			cidr:        "fd00::/64",
+			expectError: false,
+		},
+		"missing prefix length": {
+			cidr:        "10.244.1.0",
+			expectError: true,
+		},
+		"invalid address": {
+			cidr:        "10.244.300.0/24",
+			expectError: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsDiscard()
+			options := cmd.NewIPsOptions(streams)
+			options.SetCIDR(tc.cidr)
+
+			err := options.Validate()
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestIPsOptionsValidateIPFamily(t *testing.T) {
+	tests := map[string]struct {
+		ipFamily    string
+		expectError bool
+	}{
+		"all families": {
+			ipFamily:    "all",
+			expectError: false,
+		},
+		"ipv4 family": {
+			ipFamily:    "ipv4",
+			expectError: false,
+		},
+		"ipv6 family": {
+			ipFamily:    "ipv6",
+			expectError: false,
+		},
+		"invalid family": {
+			ipFamily:    "ipv5",
+			expectError: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsDiscard()
+			options := cmd.NewIPsOptions(streams)
+			options.SetIPFamily(tc.ipFamily)
+
+			err := options.Validate()
+			if tc.expectError {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, cmd.ErrUnsupportedIPFamily)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestIPsOptionsValidateIPSource(t *testing.T) {
+	for _, source := range []string{"status", "annotation"} {
+		options := cmd.NewIPsOptions(genericiooptions.NewTestIOStreamsDiscard())
+		options.SetIPSource(source)
+		assert.NoError(t, options.Validate(), source)
+	}
+
+	options := cmd.NewIPsOptions(genericiooptions.NewTestIOStreamsDiscard())
+	options.SetIPSource("spec")
+	assert.ErrorIs(t, options.Validate(), cmd.ErrUnsupportedIPSource)
+}
+
+func TestIPsOptionsValidateLookup(t *testing.T) {
+	tests := map[string]struct {
+		lookup      string
+		expectError bool
+	}{
+		"no lookup": {
+			lookup:      "",
+			expectError: false,
+		},
+		"ipv4 address": {
+			lookup:      "10.244.3.17",
+			expectError: false,
+		},
+		"ipv6 address": {
+			lookup:      "fd00::17",
+			expectError: false,
+		},
+		"invalid address": {
+			lookup:      "10.244.3",
+			expectError: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsDiscard()
+			options := cmd.NewIPsOptions(streams)
+			options.SetLookup(tc.lookup)
+
+			err := options.Validate()
+			if tc.expectError {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, cmd.ErrInvalidIP)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestIPsOptionsValidateTimeout(t *testing.T) {
+	tests := map[string]struct {
+		timeout     time.Duration
+		expectError bool
+	}{
+		"no timeout": {
+			timeout:     0,
+			expectError: false,
+		},
+		"positive timeout": {
+			timeout:     30 * time.Second,
+			expectError: false,
+		},
+		"negative timeout": {
+			timeout:     -time.Second,
+			expectError: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsDiscard()
+			options := cmd.NewIPsOptions(streams)
+			options.SetTimeout(tc.timeout)
+
+			err := options.Validate()
+			if tc.expectError {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, cmd.ErrInvalidTimeout)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestIPsOptionsValidateChunkSize(t *testing.T) {
+	tests := map[string]struct {
+		chunkSize   int64
+		expectError bool
+	}{
+		"default chunk size": {
+			chunkSize:   500,
+			expectError: false,
+		},
+		"chunking disabled": {
+			chunkSize:   0,
+			expectError: false,
+		},
+		"negative chunk size": {
+			chunkSize:   -1,
+			expectError: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsDiscard()
+			options := cmd.NewIPsOptions(streams)
+			options.SetChunkSize(tc.chunkSize)
+
+			err := options.Validate()
+			if tc.expectError {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, cmd.ErrInvalidChunkSize)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestIPsOptionsValidateSince(t *testing.T) {
+	options := cmd.NewIPsOptions(genericiooptions.NewTestIOStreamsDiscard())
+	options.SetSince(10 * time.Minute)
+	require.NoError(t, options.Validate())
+
+	options.SetSince(-time.Minute)
+	assert.ErrorIs(t, options.Validate(), cmd.ErrInvalidSince)
+
+	options.SetSince(10 * time.Minute)
+	options.SetNodes(true)
+	assert.ErrorIs(t, options.Validate(), cmd.ErrIncompatibleFlags)
+}
+
+func TestIPsOptionsValidateOlderThan(t *testing.T) {
+	tests := map[string]struct {
+		since       time.Duration
+		olderThan   time.Duration
+		nodes       bool
+		expectedErr error
+	}{
+		"older than a day":        {olderThan: 24 * time.Hour},
+		"within the since window": {since: 2 * time.Hour, olderThan: time.Hour},
+		"negative":                {olderThan: -time.Hour, expectedErr: cmd.ErrInvalidOlderThan},
+		"empty window":            {since: time.Hour, olderThan: time.Hour, expectedErr: cmd.ErrIncompatibleFlags},
+		"with nodes":              {olderThan: time.Hour, nodes: true, expectedErr: cmd.ErrIncompatibleFlags},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			options := cmd.NewIPsOptions(genericiooptions.NewTestIOStreamsDiscard())
+			options.SetSince(tc.since)
+			options.SetOlderThan(tc.olderThan)
+			options.SetNodes(tc.nodes)
+
+			err := options.Validate()
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := map[string]struct {
+		value       string
+		expected    time.Duration
+		expectError bool
+	}{
+		"minutes":           {value: "10m", expected: 10 * time.Minute},
+		"days":              {value: "30d", expected: 30 * 24 * time.Hour},
+		"days and hours":    {value: "1d12h", expected: 36 * time.Hour},
+		"negative days":     {value: "-2d", expected: -48 * time.Hour},
+		"fractional days":   {value: "1.5d", expectError: true},
+		"missing day count": {value: "d", expectError: true},
+		"bad remainder":     {value: "1dx", expectError: true},
+		"no unit":           {value: "30", expectError: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			duration, err := cmd.ParseDuration(tc.value)
+			if tc.expectError {
+				assert.Error(t, err)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, duration)
+		})
+	}
+}
+
+func TestIPsOptionsValidateFilename(t *testing.T) {
+	tests := map[string]struct {
+		setup       func(o *cmd.IPsOptions)
+		expectError bool
+	}{
+		"label selector": {setup: func(o *cmd.IPsOptions) { o.SetLabelSelector("app=web") }},
+		"field selector": {
+			setup:       func(o *cmd.IPsOptions) { o.SetFieldSelector("status.phase=Running") },
+			expectError: true,
+		},
+		"watch":            {setup: func(o *cmd.IPsOptions) { o.SetWatch(true) }, expectError: true},
+		"nodes":            {setup: func(o *cmd.IPsOptions) { o.SetNodes(true) }, expectError: true},
+		"include services": {setup: func(o *cmd.IPsOptions) { o.SetIncludeServices(true) }, expectError: true},
+		"service":          {setup: func(o *cmd.IPsOptions) { o.SetService("web") }, expectError: true},
+		"node labels": {
+			setup:       func(o *cmd.IPsOptions) { o.SetNodeLabelColumns([]string{"topology.kubernetes.io/zone"}) },
+			expectError: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			options := cmd.NewIPsOptions(genericiooptions.NewTestIOStreamsDiscard())
+			options.SetFilename("pods.yaml")
+			tc.setup(options)
+
+			err := options.Validate()
+			if tc.expectError {
+				assert.ErrorIs(t, err, cmd.ErrIncompatibleFlags)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestIPsOptionsValidateRetries(t *testing.T) {
+	tests := map[string]struct {
+		retries     int
+		expectError bool
+	}{
+		"default retries": {
+			retries:     3,
+			expectError: false,
+		},
+		"retries disabled": {
+			retries:     0,
+			expectError: false,
+		},
+		"negative retries": {
+			retries:     -1,
+			expectError: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsDiscard()
+			options := cmd.NewIPsOptions(streams)
+			options.SetRetries(tc.retries)
+
+			err := options.Validate()
+			if tc.expectError {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, cmd.ErrInvalidRetries)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestIPsOptionsValidateSortBy(t *testing.T) {
+	tests := map[string]struct {
+		sortBy      string
+		expectError bool
+	}{
+		"default order": {
+			sortBy:      "",
+			expectError: false,
+		},
+		"ip order": {
+			sortBy:      "ip",
+			expectError: false,
+		},
+		"age order": {
+			sortBy:      "age",
+			expectError: false,
+		},
+		"restarts order": {
+			sortBy:      "restarts",
+			expectError: false,
+		},
+		"status order": {
+			sortBy:      "status",
+			expectError: false,
+		},
+		"unknown key": {
+			sortBy:      "color",
+			expectError: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsDiscard()
+			options := cmd.NewIPsOptions(streams)
+			options.SetSortBy(tc.sortBy)
+
+			err := options.Validate()
+			if tc.expectError {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, cmd.ErrUnsupportedSortKey)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestIPsOptionsValidateNoSort(t *testing.T) {
+	options := cmd.NewIPsOptions(genericiooptions.NewTestIOStreamsDiscard())
+	options.SetNoSort(true)
+	require.NoError(t, options.Validate())
+
+	options.SetSortBy("ip")
+	assert.ErrorIs(t, options.Validate(), cmd.ErrIncompatibleFlags)
+}
+
+func TestIPsOptionsValidateIncludeServices(t *testing.T) {
+	tests := map[string]struct {
+		watch       bool
+		expectError bool
+	}{
+		"without watch": {
+			watch:       false,
+			expectError: false,
+		},
+		"with watch": {
+			watch:       true,
+			expectError: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsDiscard()
+			options := cmd.NewIPsOptions(streams)
+			options.SetIncludeServices(true)
+			options.SetWatch(tc.watch)
+
+			err := options.Validate()
+			if tc.expectError {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, cmd.ErrIncompatibleFlags)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestIPsOptionsValidateIncludeServicesTemplates(t *testing.T) {
+	templateFile := filepath.Join(t.TempDir(), "pods.tmpl")
+	require.NoError(t, os.WriteFile(templateFile, []byte("{{len .items}}"), 0o600))
+
+	tests := map[string][]string{
+		"with jsonpath":         {"--include-services", "-o", "jsonpath={.items[*].status.podIP}"},
+		"with go-template":      {"--include-services", "-o", "go-template={{len .items}}"},
+		"with go-template-file": {"--include-services", "-o", "go-template-file=" + templateFile},
+		"with template-file":    {"--include-services", "--template-file=" + templateFile},
+	}
+
+	for name, args := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsDiscard()
+			command := cmd.NewCmdIPs(streams)
+			command.SetArgs(append(args, "--namespace=default"))
+
+			err := command.Execute()
+			assert.ErrorIs(t, err, cmd.ErrIncompatibleFlags)
+		})
+	}
+}
+
+func TestIPsOptionsValidateNodes(t *testing.T) {
+	tests := map[string]struct {
+		setup       func(o *cmd.IPsOptions)
+		expectError bool
+	}{
+		"nodes only": {
+			setup:       func(*cmd.IPsOptions) {},
+			expectError: false,
+		},
+		"with watch": {
+			setup:       func(o *cmd.IPsOptions) { o.SetWatch(true) },
+			expectError: true,
+		},
+		"with include services": {
+			setup:       func(o *cmd.IPsOptions) { o.SetIncludeServices(true) },
+			expectError: true,
+		},
+		"with lookup": {
+			setup:       func(o *cmd.IPsOptions) { o.SetLookup("10.0.0.1") },
+			expectError: true,
+		},
+		"with count": {
+			setup:       func(o *cmd.IPsOptions) { o.SetCount(true) },
+			expectError: true,
+		},
+		"with max pods": {
+			setup:       func(o *cmd.IPsOptions) { o.SetMaxPods(10) },
+			expectError: true,
+		},
+		"with contexts": {
+			setup:       func(o *cmd.IPsOptions) { o.SetContexts([]string{"prod", "staging"}) },
+			expectError: true,
+		},
+		"with containers": {
+			setup:       func(o *cmd.IPsOptions) { o.SetContainers(true) },
+			expectError: true,
+		},
+		"with wide-with-ipv6": {
+			setup:       func(o *cmd.IPsOptions) { o.SetDualStack(true) },
+			expectError: true,
+		},
+		"with ipv6-expand": {
+			setup:       func(o *cmd.IPsOptions) { o.SetExpandIPv6(true) },
+			expectError: true,
+		},
+		"with show-node-labels": {
+			setup:       func(o *cmd.IPsOptions) { o.SetNodeLabelColumns([]string{"topology.kubernetes.io/zone"}) },
+			expectError: true,
+		},
+		"with all-interfaces": {
+			setup:       func(o *cmd.IPsOptions) { o.SetAllInterfaces(true) },
+			expectError: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsDiscard()
+			options := cmd.NewIPsOptions(streams)
+			options.SetNodes(true)
+			tc.setup(options)
+
+			err := options.Validate()
+			if tc.expectError {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, cmd.ErrIncompatibleFlags)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestIPsOptionsDropNotRunningPods(t *testing.T) {
+	newPods := func() *corev1.PodList {
+		return &corev1.PodList{
+			Items: []corev1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "running"},
+					Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "pending"},
+					Status:     corev1.PodStatus{Phase: corev1.PodPending},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "terminating", DeletionTimestamp: &metav1.Time{Time: time.Now()}},
+					Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+				},
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		runningOnly bool
+		expected    []string
+	}{
+		"disabled": {
+			runningOnly: false,
+			expected:    []string{"running", "pending", "terminating"},
+		},
+		"enabled": {
+			runningOnly: true,
+			expected:    []string{"running"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			options := cmd.NewIPsOptions(genericiooptions.NewTestIOStreamsDiscard())
+			options.SetRunningOnly(tc.runningOnly)
+
+			pods := newPods()
+			options.DropNotRunningPods(pods)
+
+			names := make([]string, 0, len(pods.Items))
+			for _, pod := range pods.Items {
+				names = append(names, pod.Name)
+			}
+			assert.Equal(t, tc.expected, names)
+		})
+	}
+}
+
+func TestIPsOptionsValidateHostNetwork(t *testing.T) {
+	tests := map[string]struct {
+		excludeHostNetwork bool
+		hostNetworkOnly    bool
+		expectError        bool
+	}{
+		"exclude host network": {
+			excludeHostNetwork: true,
+			expectError:        false,
+		},
+		"host network only": {
+			hostNetworkOnly: true,
+			expectError:     false,
+		},
+		"both host network filters": {
+			excludeHostNetwork: true,
+			hostNetworkOnly:    true,
+			expectError:        true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsDiscard()
+			options := cmd.NewIPsOptions(streams)
+			options.SetHostNetworkFilters(tc.excludeHostNetwork, tc.hostNetworkOnly)
+
+			err := options.Validate()
+			if tc.expectError {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, cmd.ErrIncompatibleFlags)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestIPsOptionsValidateCount(t *testing.T) {
+	tests := map[string]struct {
+		watch       bool
+		expectError bool
+	}{
+		"without watch": {
+			watch:       false,
+			expectError: false,
+		},
+		"with watch": {
+			watch:       true,
+			expectError: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsDiscard()
+			options := cmd.NewIPsOptions(streams)
+			options.SetCount(true)
+			options.SetWatch(tc.watch)
+
+			err := options.Validate()
+			if tc.expectError {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, cmd.ErrIncompatibleFlags)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestIPsOptionsValidateDualStack(t *testing.T) {
+	streams := genericiooptions.NewTestIOStreamsDiscard()
+	options := cmd.NewIPsOptions(streams)
+	options.SetDualStack(true)
+	require.NoError(t, options.Validate())
+
+	options.SetShowIPsOnly(true)
+	assert.ErrorIs(t, options.Validate(), cmd.ErrIncompatibleFlags)
+
+	options.SetShowIPsOnly(false)
+	options.SetAllInterfaces(true)
+	assert.ErrorIs(t, options.Validate(), cmd.ErrIncompatibleFlags)
+
+	options.SetAllInterfaces(false)
+	for _, format := range []string{"hosts", "prometheus", "json-ips", "jsonl"} {
+		options.SetOutputFormat(format)
+		assert.ErrorIs(t, options.Validate(), cmd.ErrIncompatibleFlags, format)
+	}
+}
+
+func TestIPsOptionsValidateMaxPods(t *testing.T) {
+	tests := map[string]struct {
+		maxPods     int
+		watch       bool
+		expectedErr error
+	}{
+		"no limit": {
+			maxPods: 0,
+		},
+		"positive limit": {
+			maxPods: 10,
+		},
+		"negative limit": {
+			maxPods:     -1,
+			expectedErr: cmd.ErrInvalidMaxPods,
+		},
+		"with watch": {
+			maxPods:     10,
+			watch:       true,
+			expectedErr: cmd.ErrIncompatibleFlags,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsDiscard()
+			options := cmd.NewIPsOptions(streams)
+			options.SetMaxPods(tc.maxPods)
+			options.SetWatch(tc.watch)
+
+			err := options.Validate()
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestIPsOptionsValidateMaxColumnWidth(t *testing.T) {
+	for _, width := range []int{0, 40} {
+		options := cmd.NewIPsOptions(genericiooptions.NewTestIOStreamsDiscard())
+		options.SetMaxColumnWidth(width)
+		assert.NoError(t, options.Validate())
+	}
+
+	options := cmd.NewIPsOptions(genericiooptions.NewTestIOStreamsDiscard())
+	options.SetMaxColumnWidth(-1)
+	assert.ErrorIs(t, options.Validate(), cmd.ErrInvalidMaxColumnWidth)
+}
+
+func TestIPsOptionsValidateCompact(t *testing.T) {
+	tests := map[string]struct {
+		outputFormat string
+		expectError  bool
+	}{
+		"json":     {outputFormat: "json"},
+		"json-ips": {outputFormat: "json-ips"},
+		"yaml":     {outputFormat: "yaml", expectError: true},
+		"table":    {outputFormat: "table", expectError: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			options := cmd.NewIPsOptions(genericiooptions.NewTestIOStreamsDiscard())
+			options.SetOutputFormat(tc.outputFormat)
+			options.SetCompact(true)
+
+			err := options.Validate()
+			if tc.expectError {
+				assert.ErrorIs(t, err, cmd.ErrIncompatibleFlags)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestIPsOptionsValidateNodeLabelColumns(t *testing.T) {
+	options := cmd.NewIPsOptions(genericiooptions.NewTestIOStreamsDiscard())
+	options.SetNodeLabelColumns([]string{"topology.kubernetes.io/zone"})
+	require.NoError(t, options.Validate())
+
+	options.SetWatch(true)
+	assert.ErrorIs(t, options.Validate(), cmd.ErrIncompatibleFlags)
+}
+
+func TestIPsOptionsValidateContexts(t *testing.T) {
+	tests := map[string]struct {
+		args        []string
+		expectedErr error
+	}{
+		"with watch": {
+			args:        []string{"--contexts=prod,staging", "--watch"},
+			expectedErr: cmd.ErrIncompatibleFlags,
+		},
+		"with context": {
+			args:        []string{"--contexts=prod,staging", "--context=prod"},
+			expectedErr: cmd.ErrIncompatibleFlags,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsDiscard()
+			command := cmd.NewCmdIPs(streams)
+			command.SetArgs(append(tc.args, "--namespace=default"))
+
+			err := command.Execute()
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestIPsOptionsValidateService(t *testing.T) {
+	tests := map[string]struct {
+		args        []string
+		expectedErr error
+	}{
+		"with pod names": {
+			args:        []string{"--service=web", "web-1"},
+			expectedErr: cmd.ErrIncompatibleFlags,
+		},
+		"with selector": {
+			args:        []string{"--service=web", "--selector=app=web"},
+			expectedErr: cmd.ErrIncompatibleFlags,
+		},
+		"with all namespaces": {
+			args:        []string{"--service=web", "--all-namespaces"},
+			expectedErr: cmd.ErrIncompatibleFlags,
+		},
+		"with nodes": {
+			args:        []string{"--service=web", "--nodes"},
+			expectedErr: cmd.ErrIncompatibleFlags,
+		},
+		"endpoints without service": {
+			args:        []string{"--include-endpoints"},
+			expectedErr: cmd.ErrIncompatibleFlags,
+		},
+		"endpoints with watch": {
+			args:        []string{"--service=web", "--include-endpoints", "--watch"},
+			expectedErr: cmd.ErrIncompatibleFlags,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsDiscard()
+			command := cmd.NewCmdIPs(streams)
+			command.SetArgs(append(tc.args, "--namespace=default"))
+
+			err := command.Execute()
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestIPsOptionsValidateWait(t *testing.T) {
+	tests := map[string]struct {
+		args        []string
+		expectedErr error
 	}{
-		"valid table format": {
-			outputFormat: "table",
-			expectError:  false,
+		"zero count": {
+			args:        []string{"--wait", "--wait-count=0"},
+			expectedErr: cmd.ErrInvalidWaitCount,
 		},
-		"valid wide format": {
-			outputFormat: "wide",
-			expectError:  false,
+		"negative timeout": {
+			args:        []string{"--wait", "--wait-timeout=-1s"},
+			expectedErr: cmd.ErrInvalidWaitTimeout,
 		},
-		"valid json format": {
-			outputFormat: "json",
-			expectError:  false,
+		"with watch": {
+			args:        []string{"--wait", "--watch"},
+			expectedErr: cmd.ErrIncompatibleFlags,
 		},
-		"valid yaml format": {
-			outputFormat: "yaml",
-			expectError:  false,
+		"with nodes": {
+			args:        []string{"--wait", "--nodes"},
+			expectedErr: cmd.ErrIncompatibleFlags,
 		},
-		"valid name format": {
-			outputFormat: "name",
-			expectError:  false,
+		"with filename": {
+			args:        []string{"--wait", "--filename=pods.yaml"},
+			expectedErr: cmd.ErrIncompatibleFlags,
 		},
-		"empty format": {
-			outputFormat: "",
-			expectError:  false,
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsDiscard()
+			command := cmd.NewCmdIPs(streams)
+			command.SetArgs(append(tc.args, "--namespace=default"))
+
+			err := command.Execute()
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestIPsOptionsValidateRawIPs(t *testing.T) {
+	tests := map[string][]string{
+		"with show-ips-only":  {"--raw-ips", "--show-ips-only"},
+		"with wide-with-ipv6": {"--raw-ips", "--wide-with-ipv6"},
+		"with count":          {"--raw-ips", "--count"},
+		"with summary":        {"--raw-ips", "--summary"},
+		"with watch":          {"--raw-ips", "--watch"},
+		"with nodes":          {"--raw-ips", "--nodes"},
+	}
+
+	for name, args := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsDiscard()
+			command := cmd.NewCmdIPs(streams)
+			command.SetArgs(append(args, "--namespace=default"))
+
+			err := command.Execute()
+			assert.ErrorIs(t, err, cmd.ErrIncompatibleFlags)
+		})
+	}
+}
+
+func TestIPsOptionsValidateIPsDelimiter(t *testing.T) {
+	tests := map[string]struct {
+		args        []string
+		expectedErr error
+	}{
+		"without show-ips-only": {
+			args:        []string{"--ips-delimiter=,"},
+			expectedErr: cmd.ErrIncompatibleFlags,
 		},
-		"invalid format": {
-			outputFormat: "invalid",
-			expectError:  true,
+		"invalid escape sequence": {
+			args:        []string{"--show-ips-only", `--ips-delimiter=\x`},
+			expectedErr: cmd.ErrInvalidDelimiter,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsDiscard()
+			command := cmd.NewCmdIPs(streams)
+			command.SetArgs(append(tc.args, "--namespace=default"))
+
+			err := command.Execute()
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestIPsOptionsValidateDiff(t *testing.T) {
+	tests := map[string][]string{
+		"with json output":    {"--diff=before.json", "-o", "json"},
+		"with count":          {"--diff=before.json", "--count"},
+		"with summary":        {"--diff=before.json", "--summary"},
+		"with watch":          {"--diff=before.json", "--watch"},
+		"with show-ips-only":  {"--diff=before.json", "--show-ips-only"},
+		"with raw-ips":        {"--diff=before.json", "--raw-ips"},
+		"with wide-with-ipv6": {"--diff=before.json", "--wide-with-ipv6"},
+		"with nodes":          {"--diff=before.json", "--nodes"},
+	}
+
+	for name, args := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsDiscard()
+			command := cmd.NewCmdIPs(streams)
+			command.SetArgs(append(args, "--namespace=default"))
+
+			err := command.Execute()
+			assert.ErrorIs(t, err, cmd.ErrIncompatibleFlags)
+		})
+	}
+}
+
+func TestIPsOptionsValidateServerPrint(t *testing.T) {
+	tests := map[string][]string{
+		"with json output":          {"--server-print", "-o", "json"},
+		"with pod names":            {"--server-print", "web-1"},
+		"with watch":                {"--server-print", "--watch"},
+		"with nodes":                {"--server-print", "--nodes"},
+		"with count":                {"--server-print", "--count"},
+		"with show-ips-only":        {"--server-print", "--show-ips-only"},
+		"with cidr":                 {"--server-print", "--cidr=10.0.0.0/8"},
+		"with sort-by":              {"--server-print", "--sort-by=ip"},
+		"with status":               {"--server-print", "--status=Pending"},
+		"with running-only":         {"--server-print", "--running-only"},
+		"with exclude-host-network": {"--server-print", "--exclude-host-network"},
+		"with host-network-only":    {"--server-print", "--host-network-only"},
+		"with ip-family":            {"--server-print", "--ip-family=ipv6"},
+		"with since":                {"--server-print", "--since=1h"},
+		"with older-than":           {"--server-print", "--older-than=1h"},
+		"with max-pods":             {"--server-print", "--max-pods=5"},
+		"with reverse":              {"--server-print", "--reverse"},
+		"with show-labels":          {"--server-print", "--show-labels"},
+		"with show-ports":           {"--server-print", "--show-ports"},
+		"with label-columns":        {"--server-print", "-L", "app"},
+		"with containers":           {"--server-print", "--containers"},
+		"with exclude-namespace":    {"--server-print", "--exclude-namespace=kube-system"},
+		"with group-by":             {"--server-print", "--group-by=node"},
+		"with show-pending":         {"--server-print", "--show-pending"},
+		"with ip-source":            {"--server-print", "--ip-source=annotation"},
+		"with all-interfaces":       {"--server-print", "--all-interfaces"},
+		"with annotation-columns":   {"--server-print", "--annotation-columns=team"},
+		"with show-node-labels":     {"--server-print", "--show-node-labels=zone"},
+		"with show-images":          {"--server-print", "--show-images"},
+		"with show-dns":             {"--server-print", "--show-dns"},
+		"with show-age-timestamp":   {"--server-print", "--show-age-timestamp"},
+		"with aligned-columns":      {"--server-print", "--aligned-columns"},
+		"with color":                {"--server-print", "--color=always"},
+		"with header-style":         {"--server-print", "--header-style=lower"},
+	}
+
+	for name, args := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsDiscard()
+			command := cmd.NewCmdIPs(streams)
+			command.SetArgs(append(args, "--namespace=default"))
+
+			err := command.Execute()
+			assert.ErrorIs(t, err, cmd.ErrIncompatibleFlags)
+		})
+	}
+}
+
+func TestIPsOptionsValidateSummary(t *testing.T) {
+	tests := map[string][]string{
+		"with json output":   {"--summary", "-o", "json"},
+		"with count":         {"--summary", "--count"},
+		"with watch":         {"--summary", "--watch"},
+		"with show-ips-only": {"--summary", "--show-ips-only"},
+		"with nodes":         {"--summary", "--nodes"},
+	}
+
+	for name, args := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsDiscard()
+			command := cmd.NewCmdIPs(streams)
+			command.SetArgs(append(args, "--namespace=default"))
+
+			err := command.Execute()
+			assert.ErrorIs(t, err, cmd.ErrIncompatibleFlags)
+		})
+	}
+}
+
+func TestIPsOptionsValidateSelectorFromPod(t *testing.T) {
+	tests := map[string][]string{
+		"with pod names":      {"--selector-from-pod=web-1", "web-2"},
+		"with selector":       {"--selector-from-pod=web-1", "--selector=app=web"},
+		"with service":        {"--selector-from-pod=web-1", "--service=web"},
+		"with all namespaces": {"--selector-from-pod=web-1", "--all-namespaces"},
+		"with contexts":       {"--selector-from-pod=web-1", "--contexts=dev,prod"},
+		"with filename":       {"--selector-from-pod=web-1", "--filename=pods.yaml"},
+		"with nodes":          {"--selector-from-pod=web-1", "--nodes"},
+	}
+
+	for name, args := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsDiscard()
+			command := cmd.NewCmdIPs(streams)
+			command.SetArgs(append(args, "--namespace=default"))
+
+			err := command.Execute()
+			assert.ErrorIs(t, err, cmd.ErrIncompatibleFlags)
+		})
+	}
+}
+
+func TestIPsOptionsValidateGroupBy(t *testing.T) {
+	tests := map[string]struct {
+		args        []string
+		expectedErr error
+	}{
+		"unknown key":        {args: []string{"--group-by=zone"}, expectedErr: cmd.ErrUnsupportedGroupBy},
+		"with json output":   {args: []string{"--group-by=node", "-o", "json"}, expectedErr: cmd.ErrIncompatibleFlags},
+		"with watch":         {args: []string{"--group-by=node", "--watch"}, expectedErr: cmd.ErrIncompatibleFlags},
+		"with count":         {args: []string{"--group-by=namespace", "--count"}, expectedErr: cmd.ErrIncompatibleFlags},
+		"with show-ips-only": {args: []string{"--group-by=node", "--show-ips-only"}, expectedErr: cmd.ErrIncompatibleFlags},
+		"with nodes":         {args: []string{"--group-by=node", "--nodes"}, expectedErr: cmd.ErrIncompatibleFlags},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsDiscard()
+			command := cmd.NewCmdIPs(streams)
+			command.SetArgs(append(tc.args, "--namespace=default"))
+
+			err := command.Execute()
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestIPsOptionsValidateColor(t *testing.T) {
+	tests := map[string]struct {
+		color       string
+		expectError bool
+	}{
+		"auto": {
+			color:       "auto",
+			expectError: false,
+		},
+		"always": {
+			color:       "always",
+			expectError: false,
+		},
+		"never": {
+			color:       "never",
+			expectError: false,
+		},
+		"unsupported": {
+			color:       "rainbow",
+			expectError: true,
 		},
 	}
 
@@ -125,11 +1428,12 @@ func TestIPsOptionsValidate(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			streams := genericiooptions.NewTestIOStreamsDiscard()
 			options := cmd.NewIPsOptions(streams)
-			options.SetOutputFormat(tc.outputFormat)
+			options.SetColor(tc.color)
 
 			err := options.Validate()
 			if tc.expectError {
-				assert.Error(t, err)
+				require.Error(t, err)
+				assert.ErrorIs(t, err, cmd.ErrUnsupportedColorMode)
 			} else {
 				assert.NoError(t, err)
 			}
@@ -137,6 +1441,279 @@ func TestIPsOptionsValidate(t *testing.T) {
 	}
 }
 
+func TestIPsOptionsValidateHeaderStyle(t *testing.T) {
+	for _, style := range []string{"upper", "lower", "title"} {
+		t.Run(style, func(t *testing.T) {
+			options := cmd.NewIPsOptions(genericiooptions.NewTestIOStreamsDiscard())
+			options.SetHeaderStyle(style)
+			assert.NoError(t, options.Validate())
+		})
+	}
+
+	options := cmd.NewIPsOptions(genericiooptions.NewTestIOStreamsDiscard())
+	options.SetHeaderStyle("camel")
+	assert.ErrorIs(t, options.Validate(), cmd.ErrUnsupportedHeaderStyle)
+}
+
+func TestIPsOptionsValidateTimezone(t *testing.T) {
+	for _, timezone := range []string{"Local", "UTC", "Europe/Berlin"} {
+		t.Run(timezone, func(t *testing.T) {
+			options := cmd.NewIPsOptions(genericiooptions.NewTestIOStreamsDiscard())
+			options.SetTimezone(timezone)
+			assert.NoError(t, options.Validate())
+		})
+	}
+
+	options := cmd.NewIPsOptions(genericiooptions.NewTestIOStreamsDiscard())
+	options.SetTimezone("Mars/Olympus_Mons")
+	assert.ErrorIs(t, options.Validate(), cmd.ErrUnknownTimezone)
+}
+
+func TestIPsOptionsValidatePodNameRegex(t *testing.T) {
+	options := cmd.NewIPsOptions(genericiooptions.NewTestIOStreamsDiscard())
+	options.SetPodNameRegex(`^worker-\d+$`)
+	require.NoError(t, options.Validate())
+
+	options.SetPodNameRegex("worker-(")
+	err := options.Validate()
+	require.ErrorIs(t, err, cmd.ErrInvalidPodNameRegex)
+	assert.Contains(t, err.Error(), `"worker-("`)
+
+	options = cmd.NewIPsOptions(genericiooptions.NewTestIOStreamsDiscard())
+	options.SetExcludePodRegex("[debug")
+	err = options.Validate()
+	require.ErrorIs(t, err, cmd.ErrInvalidPodNameRegex)
+	assert.Contains(t, err.Error(), `"[debug"`)
+}
+
+func TestIPsCommandTemplateFile(t *testing.T) {
+	dir := t.TempDir()
+	validTemplate := filepath.Join(dir, "valid.tmpl")
+	require.NoError(t, os.WriteFile(validTemplate, []byte("{{range .items}}{{.status.podIP}}{{end}}"), 0o600))
+	invalidTemplate := filepath.Join(dir, "invalid.tmpl")
+	require.NoError(t, os.WriteFile(invalidTemplate, []byte("{{range .items}"), 0o600))
+
+	tests := map[string]struct {
+		args        []string
+		expectedErr error
+		errContains string
+	}{
+		"missing file": {
+			args:        []string{"--template-file=" + filepath.Join(dir, "missing.tmpl")},
+			errContains: "failed to read go-template file",
+		},
+		"invalid template": {
+			args:        []string{"--template-file=" + invalidTemplate},
+			expectedErr: cmd.ErrInvalidTemplate,
+		},
+		"with output format": {
+			args:        []string{"--template-file=" + validTemplate, "-o", "json"},
+			expectedErr: cmd.ErrIncompatibleFlags,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsDiscard()
+			command := cmd.NewCmdIPs(streams)
+			command.SetArgs(append(tc.args, "--namespace=default"))
+
+			err := command.Execute()
+			require.Error(t, err)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			}
+			if tc.errContains != "" {
+				assert.Contains(t, err.Error(), tc.errContains)
+			}
+		})
+	}
+}
+
+func TestIPsCommandSelectorFile(t *testing.T) {
+	dir := t.TempDir()
+	validSelector := filepath.Join(dir, "valid.txt")
+	require.NoError(t, os.WriteFile(validSelector, []byte("env in (prod, staging),!canary\n"), 0o600))
+	invalidSelector := filepath.Join(dir, "invalid.txt")
+	require.NoError(t, os.WriteFile(invalidSelector, []byte("env in (prod\n"), 0o600))
+
+	tests := map[string]struct {
+		args        []string
+		expectedErr error
+		errContains string
+	}{
+		"missing file": {
+			args:        []string{"--selector-file=" + filepath.Join(dir, "missing.txt")},
+			errContains: "failed to read selector file",
+		},
+		"invalid selector": {
+			args:        []string{"--selector-file=" + invalidSelector},
+			errContains: "invalid label selector",
+		},
+		"with selector": {
+			args:        []string{"--selector-file=" + validSelector, "--selector=app=web"},
+			expectedErr: cmd.ErrIncompatibleFlags,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsDiscard()
+			command := cmd.NewCmdIPs(streams)
+			command.SetArgs(append(tc.args, "--namespace=default"))
+
+			err := command.Execute()
+			require.Error(t, err)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			}
+			if tc.errContains != "" {
+				assert.Contains(t, err.Error(), tc.errContains)
+			}
+		})
+	}
+}
+
+func TestIPsCommandPodNames(t *testing.T) {
+	tests := map[string]struct {
+		args        []string
+		expectedErr error
+	}{
+		"names with all namespaces": {
+			args:        []string{"nginx-1", "--all-namespaces"},
+			expectedErr: cmd.ErrNamesWithAllNamespaces,
+		},
+		"names with label selector": {
+			args:        []string{"nginx-1", "--selector=app=nginx"},
+			expectedErr: cmd.ErrNamesWithSelector,
+		},
+		"names with field selector": {
+			args:        []string{"nginx-1", "nginx-2", "--field-selector=status.phase=Running"},
+			expectedErr: cmd.ErrNamesWithSelector,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams := genericiooptions.NewTestIOStreamsDiscard()
+			command := cmd.NewCmdIPs(streams)
+			command.SetArgs(tc.args)
+
+			err := command.Execute()
+			require.Error(t, err)
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestIPsOptionsImpersonation(t *testing.T) {
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(kubeconfig, []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: dev
+  cluster:
+    server: https://dev.example.com
+- name: prod
+  cluster:
+    server: https://prod.example.com
+users:
+- name: admin
+  user:
+    token: secret
+contexts:
+- name: dev
+  context:
+    cluster: dev
+    user: admin
+- name: prod
+  context:
+    cluster: prod
+    user: admin
+current-context: dev
+`), 0o600))
+
+	options := cmd.NewIPsOptions(genericiooptions.NewTestIOStreamsDiscard())
+	flags := pflag.NewFlagSet("ips", pflag.ContinueOnError)
+	options.AddConfigFlags(flags)
+	require.NoError(t, flags.Parse([]string{
+		"--kubeconfig=" + kubeconfig, "--as=alice", "--as-group=devs", "--as-group=oncall", "--as-uid=1234",
+	}))
+
+	assertImpersonated := func(t *testing.T, config *rest.Config) {
+		t.Helper()
+		assert.Equal(t, "alice", config.Impersonate.UserName)
+		assert.Equal(t, "1234", config.Impersonate.UID)
+		assert.Equal(t, []string{"devs", "oncall"}, config.Impersonate.Groups)
+	}
+
+	config, err := options.RESTConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "https://dev.example.com", config.Host)
+	assertImpersonated(t, config)
+
+	// contexts passed to --contexts get their own config, which must impersonate the same user
+	config, err = options.ContextRESTConfig("prod")
+	require.NoError(t, err)
+	assert.Equal(t, "https://prod.example.com", config.Host)
+	assertImpersonated(t, config)
+}
+
+func TestIPsOptionsBuildClientset(t *testing.T) {
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(kubeconfig, []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: dev
+  cluster:
+    server: https://dev.example.com
+contexts:
+- name: dev
+  context:
+    cluster: dev
+current-context: dev
+`), 0o600))
+
+	options := cmd.NewIPsOptions(genericiooptions.NewTestIOStreamsDiscard())
+	flags := pflag.NewFlagSet("ips", pflag.ContinueOnError)
+	options.AddConfigFlags(flags)
+	require.NoError(t, flags.Parse([]string{"--kubeconfig=" + kubeconfig}))
+
+	clientset, err := options.BuildClientset()
+	require.NoError(t, err)
+
+	// the client is built once and reused by later calls
+	again, err := options.BuildClientset()
+	require.NoError(t, err)
+	assert.Same(t, clientset, again)
+
+	// an injected client is used as is
+	injected := fake.NewClientset()
+	options.SetClientset(injected)
+	clientset, err = options.BuildClientset()
+	require.NoError(t, err)
+	assert.Same(t, injected, clientset)
+}
+
+func TestIPsOptionsBuildClientset_noKubeconfig(t *testing.T) {
+	t.Setenv("KUBECONFIG", filepath.Join(t.TempDir(), "missing"))
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	t.Setenv("KUBERNETES_MASTER", "")
+
+	options := cmd.NewIPsOptions(genericiooptions.NewTestIOStreamsDiscard())
+	flags := pflag.NewFlagSet("ips", pflag.ContinueOnError)
+	options.AddConfigFlags(flags)
+	require.NoError(t, flags.Parse(nil))
+
+	_, err := options.BuildClientset()
+	require.ErrorIs(t, err, cmd.ErrNoKubeconfig)
+
+	// a server passed on the command line needs no kubeconfig
+	require.NoError(t, flags.Parse([]string{"--server=https://api.example.com"}))
+	_, err = options.BuildClientset()
+	require.NoError(t, err)
+}
+
 func TestIPsCommandFlags(t *testing.T) {
 	streams := genericiooptions.NewTestIOStreamsDiscard()
 	command := cmd.NewCmdIPs(streams)
@@ -144,13 +1721,85 @@ func TestIPsCommandFlags(t *testing.T) {
 	flags := []string{
 		"all-namespaces",
 		"selector",
+		"selector-file",
+		"diff",
+		"server-print",
+		"pod-name-regex",
+		"exclude-pod-regex",
+		"aligned-columns",
+		"max-column-width",
+		"show-images",
+		"field-selector",
+		"cidr",
+		"ip-family",
+		"sort-by",
+		"reverse",
+		"lookup",
 		"show-ips-only",
 		"namespace",
 		"kubeconfig",
 		"context",
+		"as",
+		"as-group",
+		"as-uid",
 		"output",
 		"no-headers",
+		"header-style",
+		"quiet",
 		"show-labels",
+		"show-ports",
+		"watch",
+		"timeout",
+		"chunk-size",
+		"retries",
+		"max-pods",
+		"contexts",
+		"service",
+		"wide-with-ipv6",
+		"include-endpoints",
+		"containers",
+		"include-init-restarts",
+		"label-columns",
+		"annotation-columns",
+		"include-services",
+		"nodes",
+		"hostname-template",
+		"exclude-namespace",
+		"status",
+		"running-only",
+		"exclude-host-network",
+		"host-network-only",
+		"count",
+		"output-file",
+		"color",
+		"group-by",
+		"no-dedup",
+		"show-pending",
+		"since",
+		"older-than",
+		"filename",
+		"no-sort",
+		"ipv6-expand",
+		"show-node-labels",
+		"compact",
+		"ip-source",
+		"all-interfaces",
+		"verbose",
+		"fail-on-empty",
+		"allow-missing-template-keys",
+		"selector-from-pod",
+		"skip-invalid-ips",
+		"summary",
+		"wait",
+		"wait-count",
+		"wait-timeout",
+		"raw-ips",
+		"ips-delimiter",
+		"show-dns",
+		"show-age-timestamp",
+		"timezone",
+		"cluster-domain",
+		"template-file",
 	}
 
 	for _, flag := range flags {
@@ -164,6 +1813,10 @@ func TestIPsCommandFlags(t *testing.T) {
 	assert.NotNil(t, shortA)
 	assert.Equal(t, "all-namespaces", shortA.Name)
 
+	shortF := command.Flags().ShorthandLookup("f")
+	assert.NotNil(t, shortF)
+	assert.Equal(t, "filename", shortF.Name)
+
 	shortL := command.Flags().ShorthandLookup("l")
 	assert.NotNil(t, shortL)
 	assert.Equal(t, "selector", shortL.Name)
@@ -171,6 +1824,18 @@ func TestIPsCommandFlags(t *testing.T) {
 	shortO := command.Flags().ShorthandLookup("o")
 	assert.NotNil(t, shortO)
 	assert.Equal(t, "output", shortO.Name)
+
+	shortQ := command.Flags().ShorthandLookup("q")
+	assert.NotNil(t, shortQ)
+	assert.Equal(t, "quiet", shortQ.Name)
+
+	shortV := command.Flags().ShorthandLookup("v")
+	assert.NotNil(t, shortV)
+	assert.Equal(t, "verbose", shortV.Name)
+
+	shortW := command.Flags().ShorthandLookup("w")
+	assert.NotNil(t, shortW)
+	assert.Equal(t, "watch", shortW.Name)
 }
 
 func TestIPsCommandExecution(t *testing.T) {
@@ -196,3 +1861,142 @@ func TestIPsCommandExecution(t *testing.T) {
 	assert.Contains(t, helpOutput, "--selector")
 	assert.Contains(t, helpOutput, "--show-ips-only")
 }
+
+func TestIPsOptionsRunWithClientset(t *testing.T) {
+	clientset := fake.NewClientset(
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "nginx-1", Namespace: "default"},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning, PodIP: "10.244.0.5"},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "coredns", Namespace: "kube-system"},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning, PodIP: "10.244.0.2"},
+		},
+	)
+
+	streams, _, out, _ := genericiooptions.NewTestIOStreams()
+	options := cmd.NewIPsOptions(streams)
+	options.SetClientset(clientset)
+	options.SetNamespace("default")
+	options.SetOutputFormat("table")
+	require.NoError(t, options.Validate())
+
+	require.NoError(t, options.Run(context.Background()))
+	assert.Equal(t, "NAME      IP           STATUS    AGE\nnginx-1   10.244.0.5   Running   <unknown>\n", out.String())
+}
+
+func TestIPsCommandFlagCompletion(t *testing.T) {
+	command := cmd.NewCmdIPs(genericiooptions.NewTestIOStreamsDiscard())
+	_, ok := command.GetFlagCompletionFunc("namespace")
+	assert.True(t, ok, "namespace flag should have a completion function")
+
+	_, ok = command.GetFlagCompletionFunc("selector")
+	assert.True(t, ok, "selector flag should have a completion function")
+}
+
+func TestIPsCommandOutputCompletion(t *testing.T) {
+	command := cmd.NewCmdIPs(genericiooptions.NewTestIOStreamsDiscard())
+	complete, ok := command.GetFlagCompletionFunc("output")
+	require.True(t, ok, "output flag should have a completion function")
+
+	tests := map[string]struct {
+		toComplete        string
+		expected          []string
+		expectedDirective cobra.ShellCompDirective
+	}{
+		"wide formats": {
+			toComplete:        "wi",
+			expected:          []string{"wide", "wide-ipv6"},
+			expectedDirective: cobra.ShellCompDirectiveNoFileComp,
+		},
+		"json formats": {
+			toComplete:        "js",
+			expected:          []string{"json", "jsonpath=", "json-ips", "jsonl"},
+			expectedDirective: cobra.ShellCompDirectiveNoFileComp,
+		},
+		"single template format": {
+			toComplete:        "custom-columns-",
+			expected:          []string{"custom-columns-file="},
+			expectedDirective: cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace,
+		},
+		"typo": {
+			toComplete:        "jsom",
+			expectedDirective: cobra.ShellCompDirectiveNoFileComp,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			formats, directive := complete(command, nil, tc.toComplete)
+			assert.Equal(t, tc.expected, formats)
+			assert.Equal(t, tc.expectedDirective, directive)
+		})
+	}
+
+	formats, _ := complete(command, nil, "")
+	assert.Len(t, formats, 17)
+}
+
+func TestIPsOptionsCompleteNamespaces(t *testing.T) {
+	clientset := fake.NewClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-public"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+	)
+
+	tests := map[string]struct {
+		toComplete string
+		expected   []string
+	}{
+		"all":      {expected: []string{"default", "kube-public", "kube-system"}},
+		"prefix":   {toComplete: "kube-", expected: []string{"kube-public", "kube-system"}},
+		"no match": {toComplete: "prod"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			options := cmd.NewIPsOptions(genericiooptions.NewTestIOStreamsDiscard())
+			options.SetClientset(clientset)
+
+			assert.Equal(t, tc.expected, options.CompleteNamespaces(tc.toComplete))
+		})
+	}
+}
+
+func TestIPsOptionsLabelSelectorSuggestions(t *testing.T) {
+	newPod := func(namespace, name string, labels map[string]string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels}}
+	}
+	clientset := fake.NewClientset(
+		newPod("default", "web-1", map[string]string{"app": "web", "tier": "frontend"}),
+		newPod("default", "worker-1", map[string]string{"app": "worker", "tier": "backend", "canary": "true"}),
+		newPod("kube-system", "coredns", map[string]string{"k8s-app": "kube-dns"}),
+	)
+
+	tests := map[string]struct {
+		toComplete string
+		expected   []string
+	}{
+		"keys":               {expected: []string{"app", "canary", "tier"}},
+		"key prefix":         {toComplete: "ti", expected: []string{"tier"}},
+		"negated key":        {toComplete: "!ca", expected: []string{"!canary"}},
+		"values":             {toComplete: "app=", expected: []string{"app=web", "app=worker"}},
+		"value prefix":       {toComplete: "app==wo", expected: []string{"app==worker"}},
+		"not equal":          {toComplete: "tier!=b", expected: []string{"tier!=backend"}},
+		"after another term": {toComplete: "app=web,tier=f", expected: []string{"app=web,tier=frontend"}},
+		"unknown key":        {toComplete: "env="},
+		"set-based term":     {toComplete: "tier in (front"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			options := cmd.NewIPsOptions(genericiooptions.NewTestIOStreamsDiscard())
+			options.SetClientset(clientset)
+			options.SetNamespace("default")
+
+			suggestions, err := options.LabelSelectorSuggestions(tc.toComplete)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, suggestions)
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+)
+
+// runNodes prints the internal and external addresses of the cluster nodes instead of pod IPs.
+func (o *IPsOptions) runNodes(ctx context.Context, clientset kubernetes.Interface) error {
+	var nodes *corev1.NodeList
+	err := o.withTimeout(ctx, func(ctx context.Context) error {
+		var err error
+		nodes, err = clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{
+			LabelSelector: o.labelSelector,
+			FieldSelector: o.fieldSelector,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list nodes: %w", classifyAPIError(err))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	filter := o.ipListOptions().filter
+
+	if o.showIPsOnly {
+		var addresses []string
+		for i := range nodes.Items {
+			for _, address := range nodeAddresses(&nodes.Items[i], filter) {
+				addresses = append(addresses, address.Address)
+			}
+		}
+		if len(addresses) == 0 {
+			return o.printNoNodesFound()
+		}
+		_, _ = fmt.Fprintln(o.Out, strings.Join(addresses, o.ipsSeparator))
+
+		return nil
+	}
+
+	table := generateNodeTable(nodes, filter)
+	if len(table.Rows) == 0 {
+		return o.printNoNodesFound()
+	}
+
+	options := o.printOptions()
+	options.showNamespace = false
+	printer, err := createPrinter(o.outputFormat, options)
+	if err != nil {
+		return err
+	}
+
+	if err := printer.PrintObj(table, o.Out); err != nil {
+		return fmt.Errorf("failed to print object: %w", err)
+	}
+
+	return nil
+}
+
+// printNoNodesFound reports that no node matched like printNoPodsFound does for pods, failing with --quiet and
+// --fail-on-empty.
+func (o *IPsOptions) printNoNodesFound() error {
+	if o.quiet {
+		return ErrNoNodesFound
+	}
+
+	selectorInfo := ""
+	if o.labelSelector != "" {
+		selector, _ := labels.Parse(o.labelSelector)
+		selectorInfo = fmt.Sprintf(" matching selector %q", selector.String())
+	}
+	_, _ = fmt.Fprintf(o.ErrOut, "No nodes found%s\n", selectorInfo)
+
+	if o.failOnEmpty {
+		return ErrNoNodesFound
+	}
+
+	return nil
+}
+
+// joinNodes looks up the node running every entry for the --show-node-labels columns, listing the nodes once
+// rather than getting each of them.
+func (o *IPsOptions) joinNodes(ctx context.Context, clientset kubernetes.Interface, podIPs []podIPWithPod) error {
+	if len(podIPs) == 0 {
+		return nil
+	}
+
+	var nodes *corev1.NodeList
+	err := o.withTimeout(ctx, func(ctx context.Context) error {
+		var err error
+		nodes, err = clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to list nodes: %w", classifyAPIError(err))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	nodesByName := make(map[string]*corev1.Node, len(nodes.Items))
+	for i := range nodes.Items {
+		nodesByName[nodes.Items[i].Name] = &nodes.Items[i]
+	}
+	for i := range podIPs {
+		podIPs[i].node = nodesByName[podIPs[i].nodeName()]
+	}
+
+	return nil
+}
+
+func generateNodeTable(nodes *corev1.NodeList, filter ipFilter) *metav1.Table {
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "NODE", Type: "string"},
+			{Name: "INTERNAL-IP", Type: "string"},
+			{Name: "EXTERNAL-IP", Type: "string"},
+		},
+	}
+
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+
+		addresses := nodeAddresses(node, filter)
+		if len(addresses) == 0 {
+			continue
+		}
+
+		table.Rows = append(table.Rows, metav1.TableRow{
+			Cells: []any{
+				node.Name,
+				FormatNodeAddresses(addresses, corev1.NodeInternalIP),
+				FormatNodeAddresses(addresses, corev1.NodeExternalIP),
+			},
+			Object: runtime.RawExtension{
+				Object: node,
+			},
+		})
+	}
+
+	return table
+}
+
+// nodeAddresses returns the internal and external IP addresses of the node that pass the filter.
+func nodeAddresses(node *corev1.Node, filter ipFilter) []corev1.NodeAddress {
+	var addresses []corev1.NodeAddress
+	for _, address := range node.Status.Addresses {
+		if address.Type != corev1.NodeInternalIP && address.Type != corev1.NodeExternalIP {
+			continue
+		}
+		if !filter.matches(address.Address) {
+			continue
+		}
+		addresses = append(addresses, address)
+	}
+
+	return addresses
+}
+
+// nodeListFromTable rebuilds a node list from the objects attached to the table rows.
+func nodeListFromTable(table *metav1.Table) *corev1.NodeList {
+	nodeList := &corev1.NodeList{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "List"},
+	}
+
+	for _, row := range table.Rows {
+		node, ok := row.Object.Object.(*corev1.Node)
+		if !ok {
+			continue
+		}
+
+		item := node.DeepCopy()
+		item.APIVersion = "v1"
+		item.Kind = "Node"
+		nodeList.Items = append(nodeList.Items, *item)
+	}
+
+	return nodeList
+}
@@ -0,0 +1,75 @@
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/andreygrechin/kubectl-ips/pkg/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestNode(name string, addresses ...corev1.NodeAddress) corev1.Node {
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status:     corev1.NodeStatus{Addresses: addresses},
+	}
+}
+
+func TestGenerateNodeTable(t *testing.T) {
+	nodes := &corev1.NodeList{
+		Items: []corev1.Node{
+			newTestNode("worker-1",
+				corev1.NodeAddress{Type: corev1.NodeHostName, Address: "worker-1"},
+				corev1.NodeAddress{Type: corev1.NodeInternalIP, Address: "192.168.1.11"},
+				corev1.NodeAddress{Type: corev1.NodeInternalIP, Address: "fd00:1::11"},
+				corev1.NodeAddress{Type: corev1.NodeExternalIP, Address: "203.0.113.11"},
+			),
+			newTestNode("worker-2",
+				corev1.NodeAddress{Type: corev1.NodeInternalIP, Address: "192.168.1.12"},
+			),
+			newTestNode("worker-3",
+				corev1.NodeAddress{Type: corev1.NodeInternalIP, Address: "fd00:1::13"},
+			),
+		},
+	}
+
+	tests := map[string]struct {
+		family   string
+		expected [][]any
+	}{
+		"all families": {
+			family: "all",
+			expected: [][]any{
+				{"worker-1", "192.168.1.11,fd00:1::11", "203.0.113.11"},
+				{"worker-2", "192.168.1.12", "<none>"},
+				{"worker-3", "fd00:1::13", "<none>"},
+			},
+		},
+		"ipv4 only": {
+			family: "ipv4",
+			expected: [][]any{
+				{"worker-1", "192.168.1.11", "203.0.113.11"},
+				{"worker-2", "192.168.1.12", "<none>"},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			table := cmd.GenerateNodeTable(nodes, tc.family)
+
+			require.Len(t, table.ColumnDefinitions, 3)
+			assert.Equal(t, "NODE", table.ColumnDefinitions[0].Name)
+			assert.Equal(t, "INTERNAL-IP", table.ColumnDefinitions[1].Name)
+			assert.Equal(t, "EXTERNAL-IP", table.ColumnDefinitions[2].Name)
+
+			cells := make([][]any, 0, len(table.Rows))
+			for _, row := range table.Rows {
+				cells = append(cells, row.Cells)
+			}
+			assert.Equal(t, tc.expected, cells)
+		})
+	}
+}
@@ -1,15 +1,23 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"os"
+	"slices"
+	"strings"
+	"unicode/utf8"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/client-go/util/jsonpath"
 	"sigs.k8s.io/yaml"
 )
 
@@ -18,6 +26,10 @@ var (
 	ErrExpectedTable = errors.New("expected metav1.Table")
 	// ErrExpectedPodList is returned when the object is not a PodList.
 	ErrExpectedPodList = errors.New("expected PodList")
+	// ErrInvalidCustomColumns is returned when a custom-columns spec cannot be parsed.
+	ErrInvalidCustomColumns = errors.New("invalid custom-columns spec")
+	// ErrInvalidTemplate is returned when an output template cannot be parsed.
+	ErrInvalidTemplate = errors.New("invalid output template")
 )
 
 // ResourcePrinter is an interface for printing Kubernetes objects.
@@ -25,29 +37,255 @@ type ResourcePrinter interface {
 	PrintObj(obj runtime.Object, out io.Writer) error
 }
 
-func createPrinter(outputFormat string, noHeaders, showNamespace bool) (ResourcePrinter, error) {
+// printOptions carries the output settings shared by the printers.
+type printOptions struct {
+	noHeaders        bool
+	showNamespace    bool
+	hostnameTemplate string
+	color            bool
+	headerStyle      string
+	// compact prints JSON on a single line instead of indented
+	compact bool
+	// strictTemplates fails go-template and jsonpath output on a missing key instead of printing it empty
+	strictTemplates bool
+	// alignedColumns prints tables with alignedPrinter instead of the stock table printer
+	alignedColumns bool
+	// maxColumnWidth truncates the longer table cells, zero keeping them whole
+	maxColumnWidth int
+}
+
+func createPrinter(outputFormat string, options printOptions) (ResourcePrinter, error) {
+	noHeaders := options.noHeaders
+	if spec, ok := strings.CutPrefix(outputFormat, customColumnsFormat+"="); ok {
+		return newCustomColumnsPrinter(spec, noHeaders)
+	}
+	if filename, ok := strings.CutPrefix(outputFormat, customColumnsFileFormat+"="); ok {
+		return newCustomColumnsPrinterFromFile(filename, noHeaders)
+	}
+	if template, ok := strings.CutPrefix(outputFormat, jsonPathFormat+"="); ok {
+		return newJSONPathPrinter(template, !options.strictTemplates)
+	}
+	if template, ok := strings.CutPrefix(outputFormat, goTemplateFormat+"="); ok {
+		return newGoTemplatePrinter([]byte(template), !options.strictTemplates)
+	}
+	if filename, ok := strings.CutPrefix(outputFormat, goTemplateFileFormat+"="); ok {
+		return newGoTemplatePrinterFromFile(filename, !options.strictTemplates)
+	}
+
 	switch outputFormat {
 	case jsonFormat:
-		return &jsonPrinter{}, nil
+		return &jsonPrinter{compact: options.compact}, nil
 	case yamlFormat:
 		return &yamlPrinter{}, nil
 	case nameFormat:
-		return &namePrinter{showNamespace: showNamespace}, nil
-	case tableFormat, wideFormat, "":
-		options := printers.PrintOptions{
-			NoHeaders: noHeaders,
+		return &namePrinter{showNamespace: options.showNamespace}, nil
+	case csvFormat:
+		return &csvPrinter{noHeaders: noHeaders, headerStyle: options.headerStyle}, nil
+	case tsvFormat:
+		return &tsvPrinter{noHeaders: noHeaders, headerStyle: options.headerStyle}, nil
+	case hostsFormat:
+		return &hostsPrinter{hostnameTemplate: options.hostnameTemplate}, nil
+	case prometheusFormat:
+		return &prometheusPrinter{noHeaders: noHeaders}, nil
+	case jsonIPsFormat:
+		return &jsonIPsPrinter{compact: options.compact}, nil
+	case jsonlFormat:
+		return &jsonlPrinter{}, nil
+	case tableFormat, wideFormat, wideIPv6Format, "":
+		wide := outputFormat == wideFormat || outputFormat == wideIPv6Format
+		newTablePrinter := func(noHeaders bool) ResourcePrinter {
+			if options.alignedColumns {
+				return &alignedPrinter{noHeaders: noHeaders, wide: wide, separator: alignedColumnSeparator}
+			}
+
+			return printers.NewTablePrinter(printers.PrintOptions{NoHeaders: noHeaders, Wide: wide})
+		}
+		printer := newTablePrinter(noHeaders)
+		if options.color {
+			// the header line is needed to locate the STATUS column and is dropped afterwards
+			printer = &colorTablePrinter{printer: newTablePrinter(false), noHeaders: noHeaders}
+		}
+		if !noHeaders && options.headerStyle != headerStyleUpper && options.headerStyle != "" {
+			printer = &headerStylePrinter{printer: printer, style: options.headerStyle}
+		}
+		if options.maxColumnWidth > 0 {
+			printer = &truncatingPrinter{printer: printer, maxWidth: options.maxColumnWidth}
 		}
 
-		return printers.NewTablePrinter(options), nil
+		return printer, nil
 	default:
 		return nil, ErrUnsupportedFormat
 	}
 }
 
-type jsonPrinter struct{}
+// isTemplateFormat reports whether the output format runs a jsonpath or go template on the list of pods.
+func isTemplateFormat(outputFormat string) bool {
+	for _, format := range []string{jsonPathFormat, goTemplateFormat, goTemplateFileFormat} {
+		if strings.HasPrefix(outputFormat, format+"=") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// formatHeader applies the header style to an upper-case column name. Title case capitalizes every word,
+// e.g. Host-Ip for HOST-IP.
+func formatHeader(name, style string) string {
+	var out strings.Builder
+	var prev byte
+	for i := range len(name) {
+		out.WriteByte(styleHeaderByte(name[i], prev, style))
+		prev = name[i]
+	}
+
+	return out.String()
+}
+
+func styleHeaderByte(b, prev byte, style string) byte {
+	isAlphanumeric := func(c byte) bool {
+		return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+	}
+	lower := b
+	if 'A' <= b && b <= 'Z' {
+		lower = b + 'a' - 'A'
+	}
+
+	switch {
+	case style == headerStyleLower:
+		return lower
+	case style == headerStyleTitle && isAlphanumeric(prev):
+		return lower
+	default:
+		return b
+	}
+}
+
+// headerStylePrinter restyles the header line of the stock table printer, which always prints it in upper case.
+type headerStylePrinter struct {
+	printer ResourcePrinter
+	style   string
+}
+
+func (p *headerStylePrinter) PrintObj(obj runtime.Object, out io.Writer) error {
+	return p.printer.PrintObj(obj, &headerStyleWriter{out: out, style: p.style})
+}
+
+// headerStyleWriter restyles the bytes of the first line written through it. As the style keeps the length of
+// the header, the columns stay aligned.
+type headerStyleWriter struct {
+	out   io.Writer
+	style string
+	prev  byte
+	done  bool
+}
+
+func (w *headerStyleWriter) Write(data []byte) (int, error) {
+	if w.done {
+		return w.out.Write(data)
+	}
+
+	styled := make([]byte, len(data))
+	for i, b := range data {
+		if w.done {
+			styled[i] = b
+
+			continue
+		}
+		styled[i] = styleHeaderByte(b, w.prev, w.style)
+		w.prev = b
+		w.done = b == '\n'
+	}
+
+	return w.out.Write(styled)
+}
+
+const (
+	ansiReset  = "\x1b[0m"
+	ansiRed    = "\x1b[31m"
+	ansiGreen  = "\x1b[32m"
+	ansiYellow = "\x1b[33m"
+)
+
+// colorTablePrinter prints the table with the wrapped table printer, highlighting the STATUS cell of every row.
+// The wrapped printer must print the header line.
+type colorTablePrinter struct {
+	printer   ResourcePrinter
+	noHeaders bool
+}
+
+// PrintObj renders the table first and colors the aligned output afterwards, as escape sequences inside the
+// cells would count towards the column widths and break the alignment.
+func (p *colorTablePrinter) PrintObj(obj runtime.Object, out io.Writer) error {
+	table, ok := obj.(*metav1.Table)
+	if !ok {
+		return ErrExpectedTable
+	}
+
+	var buf bytes.Buffer
+	if err := p.printer.PrintObj(table, &buf); err != nil {
+		return fmt.Errorf("failed to print table: %w", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	header, rows := lines[0], lines[1:]
+
+	statusColumn := columnIndex(table, "STATUS")
+	offset := strings.Index(header+" ", "STATUS ")
+	if statusColumn >= 0 && offset >= 0 && len(rows) == len(table.Rows) {
+		for i, row := range table.Rows {
+			if statusColumn >= len(row.Cells) {
+				continue
+			}
+			rows[i] = colorizeAt(rows[i], offset, fmt.Sprint(row.Cells[statusColumn]))
+		}
+	}
+
+	if !p.noHeaders {
+		rows = append([]string{header}, rows...)
+	}
+	for _, line := range rows {
+		if _, err := fmt.Fprintln(out, line); err != nil {
+			return fmt.Errorf("failed to write table: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// colorizeAt wraps the status found at the given offset of the line in the color matching it.
+func colorizeAt(line string, offset int, status string) string {
+	color := statusColor(status)
+	if color == "" || !strings.HasPrefix(line[min(offset, len(line)):], status) {
+		return line
+	}
+
+	return line[:offset] + color + status + ansiReset + line[offset+len(status):]
+}
+
+// statusColor picks green for healthy, yellow for transitional and red for failing pod statuses.
+func statusColor(status string) string {
+	switch {
+	case status == "Running" || status == "Completed" || status == string(corev1.PodSucceeded) || status == "Ready":
+		return ansiGreen
+	case status == "NotReady" || strings.Contains(status, "Error") || strings.Contains(status, "BackOff") ||
+		status == string(corev1.PodFailed) || status == "OOMKilled" || status == "Evicted" ||
+		status == string(corev1.PodUnknown):
+		return ansiRed
+	case status == string(corev1.PodPending) || status == "ContainerCreating" || status == "PodInitializing" ||
+		status == "Terminating" || strings.HasPrefix(status, "Init:") || strings.HasSuffix(status, "(NotReady)"):
+		return ansiYellow
+	default:
+		return ""
+	}
+}
+
+type jsonPrinter struct {
+	compact bool
+}
 
 func (p *jsonPrinter) PrintObj(obj runtime.Object, out io.Writer) error {
-	data, err := json.MarshalIndent(obj, "", "  ")
+	data, err := marshalJSON(obj, p.compact)
 	if err != nil {
 		return fmt.Errorf("failed to marshal JSON: %w", err)
 	}
@@ -58,6 +296,15 @@ func (p *jsonPrinter) PrintObj(obj runtime.Object, out io.Writer) error {
 	return nil
 }
 
+// marshalJSON encodes the value indented by two spaces, or on a single line when compact.
+func marshalJSON(value any, compact bool) ([]byte, error) {
+	if compact {
+		return json.Marshal(value)
+	}
+
+	return json.MarshalIndent(value, "", "  ")
+}
+
 type yamlPrinter struct{}
 
 func (p *yamlPrinter) PrintObj(obj runtime.Object, out io.Writer) error {
@@ -82,22 +329,630 @@ func (p *namePrinter) PrintObj(obj runtime.Object, out io.Writer) error {
 		return ErrExpectedTable
 	}
 
+	// the CLUSTER column may come first, and node tables name the node in their first column
+	nameColumn := max(columnIndex(table, "NAME"), 0)
+	namespaceColumn := columnIndex(table, "NAMESPACE")
+
 	for _, row := range table.Rows {
-		const minCellsForName = 2
-		if len(row.Cells) < minCellsForName {
+		if nameColumn >= len(row.Cells) {
 			continue
 		}
-		if p.showNamespace && len(row.Cells) > 0 {
-			_, _ = fmt.Fprintf(out, "%s/%s\n", row.Cells[0], row.Cells[1])
-		} else if len(row.Cells) > 0 {
-			_, _ = fmt.Fprintf(out, "%s\n", row.Cells[0])
+		if p.showNamespace && namespaceColumn >= 0 && namespaceColumn < len(row.Cells) {
+			_, _ = fmt.Fprintf(out, "%s/%s\n", row.Cells[namespaceColumn], row.Cells[nameColumn])
+		} else {
+			_, _ = fmt.Fprintf(out, "%s\n", row.Cells[nameColumn])
 		}
 	}
 
 	return nil
 }
 
-type ipOnlyPrinter struct{}
+type csvPrinter struct {
+	noHeaders   bool
+	headerStyle string
+}
+
+func (p *csvPrinter) PrintObj(obj runtime.Object, out io.Writer) error {
+	table, ok := obj.(*metav1.Table)
+	if !ok {
+		return ErrExpectedTable
+	}
+
+	writer := csv.NewWriter(out)
+
+	if !p.noHeaders {
+		headers := make([]string, 0, len(table.ColumnDefinitions))
+		for _, column := range table.ColumnDefinitions {
+			headers = append(headers, formatHeader(column.Name, p.headerStyle))
+		}
+		if err := writer.Write(headers); err != nil {
+			return fmt.Errorf("failed to write CSV header: %w", err)
+		}
+	}
+
+	for _, row := range table.Rows {
+		record := make([]string, 0, len(row.Cells))
+		for _, cell := range row.Cells {
+			record = append(record, fmt.Sprint(cell))
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	return nil
+}
+
+type tsvPrinter struct {
+	noHeaders   bool
+	headerStyle string
+}
+
+// PrintObj writes the table as tab-separated values without any alignment padding.
+func (p *tsvPrinter) PrintObj(obj runtime.Object, out io.Writer) error {
+	table, ok := obj.(*metav1.Table)
+	if !ok {
+		return ErrExpectedTable
+	}
+
+	if !p.noHeaders {
+		headers := make([]string, 0, len(table.ColumnDefinitions))
+		for _, column := range table.ColumnDefinitions {
+			headers = append(headers, formatHeader(column.Name, p.headerStyle))
+		}
+		if _, err := fmt.Fprintln(out, strings.Join(headers, "\t")); err != nil {
+			return fmt.Errorf("failed to write TSV header: %w", err)
+		}
+	}
+
+	// tabs and line breaks inside a value would shift the columns, so they become spaces
+	sanitizer := strings.NewReplacer("\t", " ", "\n", " ", "\r", " ")
+	for _, row := range table.Rows {
+		record := make([]string, 0, len(row.Cells))
+		for _, cell := range row.Cells {
+			record = append(record, sanitizer.Replace(fmt.Sprint(cell)))
+		}
+		if _, err := fmt.Fprintln(out, strings.Join(record, "\t")); err != nil {
+			return fmt.Errorf("failed to write TSV row: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// truncatingPrinter prints the table with the wrapped table printer after cutting the cells longer than maxWidth
+// characters, ending them with an ellipsis so that the cut stays visible. Headers are kept whole.
+type truncatingPrinter struct {
+	printer  ResourcePrinter
+	maxWidth int
+}
+
+func (p *truncatingPrinter) PrintObj(obj runtime.Object, out io.Writer) error {
+	table, ok := obj.(*metav1.Table)
+	if !ok {
+		return ErrExpectedTable
+	}
+
+	// the rows are copied so that the caller's table keeps its full cells
+	truncated := *table
+	truncated.Rows = make([]metav1.TableRow, len(table.Rows))
+	for i, row := range table.Rows {
+		row.Cells = slices.Clone(row.Cells)
+		for j, cell := range row.Cells {
+			if value, ok := cell.(string); ok {
+				row.Cells[j] = truncateCell(value, p.maxWidth)
+			}
+		}
+		truncated.Rows[i] = row
+	}
+
+	return p.printer.PrintObj(&truncated, out)
+}
+
+// truncateCell cuts the value to maxWidth characters, the last three of them replaced with "..." when there is
+// room for more.
+func truncateCell(value string, maxWidth int) string {
+	const ellipsis = "..."
+
+	runes := []rune(value)
+	if len(runes) <= maxWidth {
+		return value
+	}
+	if maxWidth <= len(ellipsis) {
+		return string(runes[:maxWidth])
+	}
+
+	return string(runes[:maxWidth-len(ellipsis)]) + ellipsis
+}
+
+// alignedColumnSeparator separates the columns of alignedPrinter, as wide as the padding of the stock table
+// printer.
+const alignedColumnSeparator = "   "
+
+// alignedPrinter prints the table in columns padded with spaces to their widest cell and joined by separator.
+// Unlike the stock table printer, which goes through a tab writer, it computes the column widths itself, leaving
+// room for per-column limits. Like it, columns with a priority are only printed in wide output.
+type alignedPrinter struct {
+	noHeaders bool
+	wide      bool
+	separator string
+}
+
+func (p *alignedPrinter) PrintObj(obj runtime.Object, out io.Writer) error {
+	table, ok := obj.(*metav1.Table)
+	if !ok {
+		return ErrExpectedTable
+	}
+	if len(table.Rows) == 0 {
+		return nil
+	}
+
+	var columns []int
+	for i, column := range table.ColumnDefinitions {
+		if p.wide || column.Priority == 0 {
+			columns = append(columns, i)
+		}
+	}
+
+	lines := make([][]string, 0, len(table.Rows)+1)
+	if !p.noHeaders {
+		header := make([]string, 0, len(columns))
+		for _, i := range columns {
+			header = append(header, strings.ToUpper(table.ColumnDefinitions[i].Name))
+		}
+		lines = append(lines, header)
+	}
+	for _, row := range table.Rows {
+		line := make([]string, 0, len(columns))
+		for _, i := range columns {
+			cell := ""
+			if i < len(row.Cells) {
+				cell = formatAlignedCell(row.Cells[i])
+			}
+			line = append(line, cell)
+		}
+		lines = append(lines, line)
+	}
+
+	widths := make([]int, len(columns))
+	for _, line := range lines {
+		for i, cell := range line {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+
+	var buf strings.Builder
+	for _, line := range lines {
+		buf.Reset()
+		for i, cell := range line {
+			buf.WriteString(cell)
+			// the last column is not padded, so that lines carry no trailing spaces
+			if i < len(line)-1 {
+				buf.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)))
+				buf.WriteString(p.separator)
+			}
+		}
+		if _, err := fmt.Fprintln(out, buf.String()); err != nil {
+			return fmt.Errorf("failed to write table: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// formatAlignedCell renders a table cell on a single line like the stock table printer: nil cells are empty,
+// strings are cut at the first line break and terminal escape sequences are neutralized.
+func formatAlignedCell(cell any) string {
+	switch value := cell.(type) {
+	case nil:
+		return ""
+	case string:
+		if end := strings.IndexAny(value, "\f\n\r"); end >= 0 {
+			value = value[:end] + "..."
+		}
+
+		return printers.EscapeTerminal(value)
+	default:
+		return printers.EscapeTerminal(fmt.Sprint(value))
+	}
+}
+
+type customColumn struct {
+	header string
+	parser *jsonpath.JSONPath
+}
+
+type customColumnsPrinter struct {
+	columns   []customColumn
+	noHeaders bool
+}
+
+func newCustomColumnsPrinter(spec string, noHeaders bool) (*customColumnsPrinter, error) {
+	if spec == "" {
+		return nil, fmt.Errorf("%w: custom-columns format specified but no custom columns given", ErrInvalidCustomColumns)
+	}
+
+	parts := strings.Split(spec, ",")
+	headers := make([]string, 0, len(parts))
+	expressions := make([]string, 0, len(parts))
+	for _, part := range parts {
+		header, expression, found := strings.Cut(part, ":")
+		if !found || header == "" || expression == "" {
+			return nil, fmt.Errorf("%w: unexpected column %q, expected <header>:<json-path-expr>",
+				ErrInvalidCustomColumns, part)
+		}
+		headers = append(headers, header)
+		expressions = append(expressions, expression)
+	}
+
+	return newCustomColumnsPrinterFromColumns(headers, expressions, noHeaders)
+}
+
+func newCustomColumnsPrinterFromFile(filename string, noHeaders bool) (*customColumnsPrinter, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read custom-columns file: %w", err)
+	}
+
+	// the file holds a line of headers followed by a line of json-path expressions
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	const expectedLines = 2
+	if len(lines) != expectedLines {
+		return nil, fmt.Errorf("%w: expected 2 lines in %s, found %d", ErrInvalidCustomColumns, filename, len(lines))
+	}
+	headers := strings.Fields(lines[0])
+	expressions := strings.Fields(lines[1])
+	if len(headers) != len(expressions) {
+		return nil, fmt.Errorf("%w: number of headers (%d) doesn't match the number of expressions (%d)",
+			ErrInvalidCustomColumns, len(headers), len(expressions))
+	}
+
+	return newCustomColumnsPrinterFromColumns(headers, expressions, noHeaders)
+}
+
+func newCustomColumnsPrinterFromColumns(headers, expressions []string, noHeaders bool) (*customColumnsPrinter, error) {
+	columns := make([]customColumn, 0, len(headers))
+	for i := range headers {
+		parser := jsonpath.New(headers[i]).AllowMissingKeys(true)
+		if err := parser.Parse(relaxedJSONPathExpression(expressions[i])); err != nil {
+			return nil, fmt.Errorf("%w: failed to parse expression %q: %w", ErrInvalidCustomColumns, expressions[i], err)
+		}
+		columns = append(columns, customColumn{header: headers[i], parser: parser})
+	}
+
+	return &customColumnsPrinter{columns: columns, noHeaders: noHeaders}, nil
+}
+
+// relaxedJSONPathExpression accepts '.metadata.name', 'metadata.name' and '{.metadata.name}' forms.
+func relaxedJSONPathExpression(expression string) string {
+	expression = strings.TrimSpace(expression)
+	if strings.HasPrefix(expression, "{") && strings.HasSuffix(expression, "}") {
+		return expression
+	}
+	if !strings.HasPrefix(expression, ".") {
+		expression = "." + expression
+	}
+
+	return "{" + expression + "}"
+}
+
+func (p *customColumnsPrinter) PrintObj(obj runtime.Object, out io.Writer) error {
+	table, ok := obj.(*metav1.Table)
+	if !ok {
+		return ErrExpectedTable
+	}
+
+	writer := printers.GetNewTabWriter(out)
+
+	if !p.noHeaders {
+		headers := make([]string, 0, len(p.columns))
+		for _, column := range p.columns {
+			headers = append(headers, strings.ToUpper(column.header))
+		}
+		_, _ = fmt.Fprintln(writer, strings.Join(headers, "\t"))
+	}
+
+	for _, row := range table.Rows {
+		cells, err := p.evaluateRow(row.Object.Object)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(writer, strings.Join(cells, "\t"))
+	}
+
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("failed to write custom columns: %w", err)
+	}
+
+	return nil
+}
+
+func (p *customColumnsPrinter) evaluateRow(obj runtime.Object) ([]string, error) {
+	data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert object: %w", err)
+	}
+
+	cells := make([]string, 0, len(p.columns))
+	for _, column := range p.columns {
+		results, err := column.parser.FindResults(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate column %s: %w", column.header, err)
+		}
+
+		values := []string{}
+		for _, result := range results {
+			for _, value := range result {
+				values = append(values, printers.EscapeTerminal(fmt.Sprint(value.Interface())))
+			}
+		}
+		if len(values) == 0 {
+			values = append(values, noneValue)
+		}
+		cells = append(cells, strings.Join(values, ","))
+	}
+
+	return cells, nil
+}
+
+type hostsPrinter struct {
+	hostnameTemplate string
+}
+
+// PrintObj writes an /etc/hosts line for every row, naming the IP after the object owning it.
+func (p *hostsPrinter) PrintObj(obj runtime.Object, out io.Writer) error {
+	table, ok := obj.(*metav1.Table)
+	if !ok {
+		return ErrExpectedTable
+	}
+
+	ipColumn := columnIndex(table, "IP")
+	if ipColumn < 0 {
+		return fmt.Errorf("%w: hosts output requires an IP column", ErrUnsupportedFormat)
+	}
+
+	template := p.hostnameTemplate
+	if template == "" {
+		template = defaultHostnameTemplate
+	}
+
+	for _, row := range table.Rows {
+		if ipColumn >= len(row.Cells) || row.Cells[ipColumn] == noneValue {
+			continue
+		}
+		object, err := meta.Accessor(row.Object.Object)
+		if err != nil {
+			return fmt.Errorf("failed to access object metadata: %w", err)
+		}
+
+		hostname := strings.NewReplacer(
+			"<name>", object.GetName(),
+			"<namespace>", object.GetNamespace(),
+		).Replace(template)
+		_, _ = fmt.Fprintf(out, "%v %s\n", row.Cells[ipColumn], hostname)
+	}
+
+	return nil
+}
+
+// prometheusMetricName is the metric reported for every pod IP by the prometheus output.
+const prometheusMetricName = "kube_pod_ip"
+
+type prometheusPrinter struct {
+	noHeaders bool
+}
+
+// PrintObj writes a Prometheus text exposition line for every pod row.
+func (p *prometheusPrinter) PrintObj(obj runtime.Object, out io.Writer) error {
+	table, ok := obj.(*metav1.Table)
+	if !ok {
+		return ErrExpectedTable
+	}
+
+	ipColumn := columnIndex(table, "IP")
+	if ipColumn < 0 {
+		return fmt.Errorf("%w: prometheus output requires an IP column", ErrUnsupportedFormat)
+	}
+
+	if !p.noHeaders {
+		_, _ = fmt.Fprintf(out, "# HELP %s Information about the IP addresses assigned to pods.\n", prometheusMetricName)
+		_, _ = fmt.Fprintf(out, "# TYPE %s gauge\n", prometheusMetricName)
+	}
+
+	for _, row := range table.Rows {
+		pod, ok := row.Object.Object.(*corev1.Pod)
+		if !ok || ipColumn >= len(row.Cells) || row.Cells[ipColumn] == noneValue {
+			continue
+		}
+
+		_, _ = fmt.Fprintf(out, "%s{namespace=\"%s\",pod=\"%s\",ip=\"%s\"} 1\n",
+			prometheusMetricName,
+			escapeLabelValue(pod.Namespace),
+			escapeLabelValue(pod.Name),
+			escapeLabelValue(fmt.Sprint(row.Cells[ipColumn])),
+		)
+	}
+
+	return nil
+}
+
+// jsonIPsPrinter writes a JSON array with an object per pod IP, simpler to consume than the table.
+type jsonIPsPrinter struct {
+	compact bool
+}
+
+// PrintObj writes the pod IPs of the table rows, leaving out services and pods without an IP.
+func (p *jsonIPsPrinter) PrintObj(obj runtime.Object, out io.Writer) error {
+	podIPs, err := tablePodIPs(obj, jsonIPsFormat)
+	if err != nil {
+		return err
+	}
+
+	data, err := marshalJSON(podIPs, p.compact)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	if _, err = fmt.Fprintln(out, string(data)); err != nil {
+		return fmt.Errorf("failed to write JSON: %w", err)
+	}
+
+	return nil
+}
+
+// jsonlPrinter writes the pod IPs of the table rows as JSON Lines, a compact object per line, for log pipelines
+// and tools such as jq -c consuming a stream.
+type jsonlPrinter struct{}
+
+// PrintObj writes the pod IPs of the table rows, leaving out services and pods without an IP.
+func (p *jsonlPrinter) PrintObj(obj runtime.Object, out io.Writer) error {
+	podIPs, err := tablePodIPs(obj, jsonlFormat)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(out)
+	for _, podIP := range podIPs {
+		if err := encoder.Encode(podIP); err != nil {
+			return fmt.Errorf("failed to write JSON: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// tablePodIPs collects the pod IPs of the table rows for the output format, leaving out services and pods
+// without an IP.
+func tablePodIPs(obj runtime.Object, format string) ([]PodIP, error) {
+	table, ok := obj.(*metav1.Table)
+	if !ok {
+		return nil, ErrExpectedTable
+	}
+
+	ipColumn := columnIndex(table, "IP")
+	if ipColumn < 0 {
+		return nil, fmt.Errorf("%w: %s output requires an IP column", ErrUnsupportedFormat, format)
+	}
+	clusterColumn := columnIndex(table, "CLUSTER")
+
+	podIPs := []PodIP{}
+	seen := make(map[PodIP]bool)
+	for _, row := range table.Rows {
+		pod, ok := row.Object.Object.(*corev1.Pod)
+		if !ok || ipColumn >= len(row.Cells) || row.Cells[ipColumn] == noneValue {
+			continue
+		}
+
+		podIP := newPodIP(pod, fmt.Sprint(row.Cells[ipColumn]))
+		if clusterColumn >= 0 && clusterColumn < len(row.Cells) {
+			podIP.Cluster = fmt.Sprint(row.Cells[clusterColumn])
+		}
+		// rows expanded per container repeat the same pod IP
+		if seen[podIP] {
+			continue
+		}
+		seen[podIP] = true
+		podIPs = append(podIPs, podIP)
+	}
+
+	return podIPs, nil
+}
+
+// escapeLabelValue escapes a Prometheus label value as required by the text exposition format.
+func escapeLabelValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+// columnIndex returns the position of the named column in the table, or -1 when it is absent.
+func columnIndex(table *metav1.Table, name string) int {
+	return slices.IndexFunc(table.ColumnDefinitions, func(column metav1.TableColumnDefinition) bool {
+		return column.Name == name
+	})
+}
+
+type jsonPathPrinter struct {
+	printer *printers.JSONPathPrinter
+}
+
+func newJSONPathPrinter(template string, allowMissingKeys bool) (*jsonPathPrinter, error) {
+	if template == "" {
+		return nil, fmt.Errorf("%w: jsonpath format specified but no template given", ErrInvalidTemplate)
+	}
+
+	printer, err := printers.NewJSONPathPrinter(template)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse jsonpath template: %w", ErrInvalidTemplate, err)
+	}
+	printer.AllowMissingKeys(allowMissingKeys)
+
+	return &jsonPathPrinter{printer: printer}, nil
+}
+
+func (p *jsonPathPrinter) PrintObj(obj runtime.Object, out io.Writer) error {
+	table, ok := obj.(*metav1.Table)
+	if !ok {
+		return ErrExpectedTable
+	}
+
+	if err := p.printer.PrintObj(listFromTable(table), out); err != nil {
+		return fmt.Errorf("failed to print jsonpath: %w", err)
+	}
+
+	return nil
+}
+
+type goTemplatePrinter struct {
+	printer *printers.GoTemplatePrinter
+}
+
+// newGoTemplatePrinter parses the template, which fails on a missing key with missingkey=error unless
+// allowMissingKeys is set.
+func newGoTemplatePrinter(template []byte, allowMissingKeys bool) (*goTemplatePrinter, error) {
+	if len(template) == 0 {
+		return nil, fmt.Errorf("%w: go-template format specified but no template given", ErrInvalidTemplate)
+	}
+
+	printer, err := printers.NewGoTemplatePrinter(template)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse go-template: %w", ErrInvalidTemplate, err)
+	}
+	printer.AllowMissingKeys(allowMissingKeys)
+
+	return &goTemplatePrinter{printer: printer}, nil
+}
+
+func newGoTemplatePrinterFromFile(filename string, allowMissingKeys bool) (*goTemplatePrinter, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read go-template file: %w", err)
+	}
+
+	return newGoTemplatePrinter(data, allowMissingKeys)
+}
+
+func (p *goTemplatePrinter) PrintObj(obj runtime.Object, out io.Writer) error {
+	table, ok := obj.(*metav1.Table)
+	if !ok {
+		return ErrExpectedTable
+	}
+
+	if err := p.printer.PrintObj(listFromTable(table), out); err != nil {
+		return fmt.Errorf("failed to print go-template: %w", err)
+	}
+
+	return nil
+}
+
+type ipOnlyPrinter struct {
+	listOptions ipListOptions
+	expandIPv6  bool
+	// delimiter separates the addresses, a newline printing one per line
+	delimiter string
+}
 
 func (p *ipOnlyPrinter) PrintObj(obj runtime.Object, out io.Writer) error {
 	pods, ok := obj.(*corev1.PodList)
@@ -105,12 +960,35 @@ func (p *ipOnlyPrinter) PrintObj(obj runtime.Object, out io.Writer) error {
 		return ErrExpectedPodList
 	}
 
-	podIPs := extractPodIPsWithPods(pods)
-	sortPodIPsWithPods(podIPs)
+	printIPs(listPodIPs(ipSources{pods: pods}, p.listOptions), out, p.expandIPv6, p.delimiter)
 
+	return nil
+}
+
+// printIPs writes the bare IP addresses separated by the delimiter and followed by a newline, so that a newline
+// delimiter prints one per line. Nothing is written when there are none.
+func printIPs(podIPs []podIPWithPod, out io.Writer, expand bool, delimiter string) {
+	ips := bareIPs(podIPs, expand)
+	if len(ips) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintln(out, strings.Join(ips, delimiter))
+}
+
+// bareIPs returns the IP addresses of the entries in order, skipping entries listed without an address.
+func bareIPs(podIPs []podIPWithPod, expand bool) []string {
+	ips := make([]string, 0, len(podIPs))
 	for _, item := range podIPs {
-		_, _ = fmt.Fprintf(out, "%s\n", item.ip)
+		if item.ip == "" {
+			continue
+		}
+		ip := item.ip
+		if expand {
+			ip = expandIPv6(ip)
+		}
+		ips = append(ips, ip)
 	}
 
-	return nil
+	return ips
 }
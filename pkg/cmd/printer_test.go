@@ -0,0 +1,658 @@
+package cmd_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/andreygrechin/kubectl-ips/pkg/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func newTestTable() *metav1.Table {
+	return &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "NAME", Type: "string"},
+			{Name: "IP", Type: "string"},
+			{Name: "LABELS", Type: "string"},
+		},
+		Rows: []metav1.TableRow{
+			{Cells: []any{"nginx-1", "10.244.0.5", "app=nginx,tier=web"}},
+			{Cells: []any{"quoted", "fd00::5", `note="x"`}},
+		},
+	}
+}
+
+func TestCSVPrinter_PrintObj(t *testing.T) {
+	tests := map[string]struct {
+		noHeaders bool
+		expected  string
+	}{
+		"with headers": {
+			noHeaders: false,
+			expected: "NAME,IP,LABELS\n" +
+				"nginx-1,10.244.0.5,\"app=nginx,tier=web\"\n" +
+				"quoted,fd00::5,\"note=\"\"x\"\"\"\n",
+		},
+		"without headers": {
+			noHeaders: true,
+			expected: "nginx-1,10.244.0.5,\"app=nginx,tier=web\"\n" +
+				"quoted,fd00::5,\"note=\"\"x\"\"\"\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			printer, err := cmd.CreatePrinter("csv", tc.noHeaders, false)
+			require.NoError(t, err)
+
+			var out bytes.Buffer
+			require.NoError(t, printer.PrintObj(newTestTable(), &out))
+			assert.Equal(t, tc.expected, out.String())
+		})
+	}
+}
+
+func TestTSVPrinter_PrintObj(t *testing.T) {
+	tests := map[string]struct {
+		noHeaders bool
+		expected  string
+	}{
+		"with headers": {
+			noHeaders: false,
+			expected: "NAME\tIP\tLABELS\n" +
+				"nginx-1\t10.244.0.5\tapp=nginx,tier=web\n" +
+				"quoted\tfd00::5\tnote=\"x\"\n",
+		},
+		"without headers": {
+			noHeaders: true,
+			expected: "nginx-1\t10.244.0.5\tapp=nginx,tier=web\n" +
+				"quoted\tfd00::5\tnote=\"x\"\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			printer, err := cmd.CreatePrinter("tsv", tc.noHeaders, false)
+			require.NoError(t, err)
+
+			var out bytes.Buffer
+			require.NoError(t, printer.PrintObj(newTestTable(), &out))
+			assert.Equal(t, tc.expected, out.String())
+		})
+	}
+}
+
+func newTestPodTable() *metav1.Table {
+	pods := []*corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "nginx-1", Namespace: "default"},
+			Spec:       corev1.PodSpec{NodeName: "worker-1"},
+			Status:     corev1.PodStatus{PodIP: "10.244.0.5"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "default"},
+			Status:     corev1.PodStatus{PodIP: "10.244.0.6"},
+		},
+	}
+
+	table := &metav1.Table{}
+	for _, pod := range pods {
+		table.Rows = append(table.Rows, metav1.TableRow{
+			Cells:  []any{pod.Name, pod.Status.PodIP},
+			Object: runtime.RawExtension{Object: pod},
+		})
+	}
+
+	return table
+}
+
+func TestCustomColumnsPrinter_PrintObj(t *testing.T) {
+	tests := map[string]struct {
+		format    string
+		noHeaders bool
+		expected  string
+	}{
+		"relaxed expressions": {
+			format: "custom-columns=NAME:.metadata.name,IP:status.podIP,NODE:{.spec.nodeName}",
+			expected: "NAME      IP           NODE\n" +
+				"nginx-1   10.244.0.5   worker-1\n" +
+				"pending   10.244.0.6   <none>\n",
+		},
+		"without headers": {
+			format:    "custom-columns=NAME:.metadata.name",
+			noHeaders: true,
+			expected:  "nginx-1\npending\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			printer, err := cmd.CreatePrinter(tc.format, tc.noHeaders, false)
+			require.NoError(t, err)
+
+			var out bytes.Buffer
+			require.NoError(t, printer.PrintObj(newTestPodTable(), &out))
+			assert.Equal(t, tc.expected, out.String())
+		})
+	}
+}
+
+func TestCustomColumnsPrinter_invalidSpec(t *testing.T) {
+	tests := map[string]string{
+		"empty spec":         "custom-columns=",
+		"missing expression": "custom-columns=NAME",
+		"empty header":       "custom-columns=:.metadata.name",
+		"malformed jsonpath": "custom-columns=NAME:{.metadata.name",
+		"missing file":       "custom-columns-file=/nonexistent/columns.txt",
+	}
+
+	for name, format := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := cmd.CreatePrinter(format, false, false)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCustomColumnsPrinter_fromFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "columns.txt")
+	require.NoError(t, os.WriteFile(filename, []byte("NAME IP\n.metadata.name .status.podIP\n"), 0o600))
+
+	printer, err := cmd.CreatePrinter("custom-columns-file="+filename, false, false)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, printer.PrintObj(newTestPodTable(), &out))
+	assert.Equal(t, "NAME      IP\nnginx-1   10.244.0.5\npending   10.244.0.6\n", out.String())
+}
+
+func TestJSONPathPrinter_PrintObj(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "dual-stack", Namespace: "default"},
+		Status: corev1.PodStatus{
+			PodIP:  "10.244.0.5",
+			PodIPs: []corev1.PodIP{{IP: "10.244.0.5"}, {IP: "fd00::5"}},
+		},
+	}
+	table := newTestPodTable()
+	table.Rows = append(table.Rows,
+		metav1.TableRow{Cells: []any{pod.Name, "10.244.0.5"}, Object: runtime.RawExtension{Object: pod}},
+		metav1.TableRow{Cells: []any{pod.Name, "fd00::5"}, Object: runtime.RawExtension{Object: pod}},
+	)
+
+	tests := map[string]struct {
+		format   string
+		expected string
+	}{
+		"pod ips": {
+			format:   `jsonpath={range .items[*]}{.metadata.name} {.status.podIP}{"\n"}{end}`,
+			expected: "nginx-1 10.244.0.5\npending 10.244.0.6\ndual-stack 10.244.0.5\n",
+		},
+		"list kind": {
+			format:   `jsonpath={.kind} {.items[0].kind}`,
+			expected: "List Pod",
+		},
+		"missing keys": {
+			format:   `jsonpath={.items[0].metadata.missing}`,
+			expected: "",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			printer, err := cmd.CreatePrinter(tc.format, false, false)
+			require.NoError(t, err)
+
+			var out bytes.Buffer
+			require.NoError(t, printer.PrintObj(table, &out))
+			assert.Equal(t, tc.expected, out.String())
+		})
+	}
+}
+
+func TestJSONPathPrinter_invalidTemplate(t *testing.T) {
+	tests := map[string]string{
+		"empty template":     "jsonpath=",
+		"unclosed action":    "jsonpath={.items[*]",
+		"unterminated array": "jsonpath={.items[0}",
+	}
+
+	for name, format := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := cmd.CreatePrinter(format, false, false)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, cmd.ErrInvalidTemplate)
+		})
+	}
+}
+
+func TestTemplatePrinters_strictTemplates(t *testing.T) {
+	tests := map[string]struct {
+		format   string
+		expected string
+	}{
+		"jsonpath": {
+			format:   `jsonpath={.items[0].metadata.missing}`,
+			expected: "",
+		},
+		"go-template": {
+			format:   `go-template={{range .items}}{{.metadata.missing}}{{end}}`,
+			expected: "<no value><no value>",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			printer, err := cmd.CreatePrinter(tc.format, false, false)
+			require.NoError(t, err)
+			var out bytes.Buffer
+			require.NoError(t, printer.PrintObj(newTestPodTable(), &out))
+			assert.Equal(t, tc.expected, out.String())
+
+			strict, err := cmd.CreateStrictTemplatePrinter(tc.format)
+			require.NoError(t, err)
+			assert.Error(t, strict.PrintObj(newTestPodTable(), &bytes.Buffer{}))
+		})
+	}
+}
+
+func TestGoTemplatePrinter_PrintObj(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "template.tmpl")
+	require.NoError(t, os.WriteFile(filename, []byte(`{{range .items}}{{.metadata.name}}{{"\n"}}{{end}}`), 0o600))
+
+	tests := map[string]struct {
+		format   string
+		expected string
+	}{
+		"inline template": {
+			format:   `go-template={{range .items}}{{.metadata.name}} {{.status.podIP}}{{"\n"}}{{end}}`,
+			expected: "nginx-1 10.244.0.5\npending 10.244.0.6\n",
+		},
+		"template file": {
+			format:   "go-template-file=" + filename,
+			expected: "nginx-1\npending\n",
+		},
+		"kubectl template funcs": {
+			format:   `go-template={{range .items}}{{if exists . "spec" "nodeName"}}{{.spec.nodeName}}{{end}}{{end}}`,
+			expected: "worker-1",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			printer, err := cmd.CreatePrinter(tc.format, false, false)
+			require.NoError(t, err)
+
+			var out bytes.Buffer
+			require.NoError(t, printer.PrintObj(newTestPodTable(), &out))
+			assert.Equal(t, tc.expected, out.String())
+		})
+	}
+}
+
+func TestGoTemplatePrinter_invalidTemplate(t *testing.T) {
+	tests := map[string]string{
+		"empty template":   "go-template=",
+		"unclosed action":  "go-template={{range .items}",
+		"unknown function": "go-template={{unknownFunc .items}}",
+	}
+
+	for name, format := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := cmd.CreatePrinter(format, false, false)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, cmd.ErrInvalidTemplate)
+		})
+	}
+
+	_, err := cmd.CreatePrinter("go-template-file=/nonexistent/template.tmpl", false, false)
+	assert.Error(t, err)
+}
+
+func TestTablePrinter_wideColumns(t *testing.T) {
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "NAME", Type: "string"},
+			{Name: "HOST-IP", Type: "string", Priority: 1},
+		},
+		Rows: []metav1.TableRow{
+			{Cells: []any{"nginx-1", "192.168.1.10"}},
+		},
+	}
+
+	tests := map[string]struct {
+		format   string
+		expected string
+	}{
+		"table hides wide columns": {
+			format:   "table",
+			expected: "NAME\nnginx-1\n",
+		},
+		"wide shows wide columns": {
+			format:   "wide",
+			expected: "NAME      HOST-IP\nnginx-1   192.168.1.10\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			printer, err := cmd.CreatePrinter(tc.format, false, false)
+			require.NoError(t, err)
+
+			var out bytes.Buffer
+			require.NoError(t, printer.PrintObj(table, &out))
+			assert.Equal(t, tc.expected, out.String())
+		})
+	}
+}
+
+func TestHostsPrinter_PrintObj(t *testing.T) {
+	table := newTestPodTable()
+	table.ColumnDefinitions = []metav1.TableColumnDefinition{
+		{Name: "NAME", Type: "string"},
+		{Name: "IP", Type: "string"},
+	}
+
+	tests := map[string]struct {
+		hostnameTemplate string
+		expected         string
+	}{
+		"default template": {
+			hostnameTemplate: "",
+			expected:         "10.244.0.5 nginx-1.default\n10.244.0.6 pending.default\n",
+		},
+		"custom template": {
+			hostnameTemplate: "<name>.<namespace>.pod.cluster.local",
+			expected: "10.244.0.5 nginx-1.default.pod.cluster.local\n" +
+				"10.244.0.6 pending.default.pod.cluster.local\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			printer, err := cmd.CreateHostsPrinter(tc.hostnameTemplate)
+			require.NoError(t, err)
+
+			var out bytes.Buffer
+			require.NoError(t, printer.PrintObj(table, &out))
+			assert.Equal(t, tc.expected, out.String())
+		})
+	}
+}
+
+func TestHostsPrinter_missingIPColumn(t *testing.T) {
+	printer, err := cmd.CreateHostsPrinter("")
+	require.NoError(t, err)
+
+	err = printer.PrintObj(newTestPodTable(), &bytes.Buffer{})
+	assert.ErrorIs(t, err, cmd.ErrUnsupportedFormat)
+}
+
+func TestPrometheusPrinter_PrintObj(t *testing.T) {
+	table := newTestPodTable()
+	table.ColumnDefinitions = []metav1.TableColumnDefinition{
+		{Name: "NAME", Type: "string"},
+		{Name: "IP", Type: "string"},
+	}
+	table.Rows = append(table.Rows, metav1.TableRow{
+		Cells: []any{`odd"name`, "10.244.0.7"},
+		Object: runtime.RawExtension{Object: &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: `odd"name`, Namespace: `team\a`},
+		}},
+	})
+
+	metrics := `kube_pod_ip{namespace="default",pod="nginx-1",ip="10.244.0.5"} 1` + "\n" +
+		`kube_pod_ip{namespace="default",pod="pending",ip="10.244.0.6"} 1` + "\n" +
+		`kube_pod_ip{namespace="team\\a",pod="odd\"name",ip="10.244.0.7"} 1` + "\n"
+
+	tests := map[string]struct {
+		noHeaders bool
+		expected  string
+	}{
+		"with headers": {
+			noHeaders: false,
+			expected: "# HELP kube_pod_ip Information about the IP addresses assigned to pods.\n" +
+				"# TYPE kube_pod_ip gauge\n" + metrics,
+		},
+		"without headers": {
+			noHeaders: true,
+			expected:  metrics,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			printer, err := cmd.CreatePrinter("prometheus", tc.noHeaders, false)
+			require.NoError(t, err)
+
+			var out bytes.Buffer
+			require.NoError(t, printer.PrintObj(table, &out))
+			assert.Equal(t, tc.expected, out.String())
+		})
+	}
+}
+
+func TestJSONIPsPrinter_PrintObj(t *testing.T) {
+	table := newTestPodTable()
+	table.ColumnDefinitions = []metav1.TableColumnDefinition{
+		{Name: "NAME", Type: "string"},
+		{Name: "IP", Type: "string"},
+	}
+	starting := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "starting", Namespace: "default"}}
+	table.Rows = append(table.Rows,
+		// a second container row of the first pod
+		metav1.TableRow{Cells: []any{"nginx-1", "10.244.0.5"}, Object: table.Rows[0].Object},
+		metav1.TableRow{Cells: []any{"starting", "<none>"}, Object: runtime.RawExtension{Object: starting}},
+		metav1.TableRow{Cells: []any{"nginx", "10.96.0.10"}, Object: runtime.RawExtension{Object: &corev1.Service{}}},
+	)
+
+	printer, err := cmd.CreatePrinter("json-ips", false, false)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, printer.PrintObj(table, &out))
+
+	var podIPs []cmd.PodIP
+	require.NoError(t, json.Unmarshal(out.Bytes(), &podIPs))
+	assert.Equal(t, []cmd.PodIP{
+		{Namespace: "default", Name: "nginx-1", Node: "worker-1", IP: "10.244.0.5", Status: ""},
+		{Namespace: "default", Name: "pending", IP: "10.244.0.6", Status: ""},
+	}, podIPs)
+}
+
+func TestJSONLPrinter_PrintObj(t *testing.T) {
+	table := newTestPodTable()
+	table.ColumnDefinitions = []metav1.TableColumnDefinition{
+		{Name: "NAME", Type: "string"},
+		{Name: "IP", Type: "string"},
+	}
+
+	printer, err := cmd.CreatePrinter("jsonl", false, false)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, printer.PrintObj(table, &out))
+	assert.Equal(t, `{"namespace":"default","pod":"nginx-1","node":"worker-1","ip":"10.244.0.5","status":""}
+{"namespace":"default","pod":"pending","ip":"10.244.0.6","status":""}
+`, out.String())
+
+	table.ColumnDefinitions = table.ColumnDefinitions[:1]
+	assert.ErrorIs(t, printer.PrintObj(table, &out), cmd.ErrUnsupportedFormat)
+}
+
+func TestColorTablePrinter_PrintObj(t *testing.T) {
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "NAME", Type: "string"},
+			{Name: "STATUS", Type: "string"},
+			{Name: "AGE", Type: "string"},
+		},
+		Rows: []metav1.TableRow{
+			{Cells: []any{"web", "Running", "2d"}},
+			{Cells: []any{"worker", "CrashLoopBackOff", "5m"}},
+			{Cells: []any{"init", "Init:0/1", "1m"}},
+			{Cells: []any{"svc", "<none>", "7d"}},
+		},
+	}
+
+	rows := "web      \x1b[32mRunning\x1b[0m            2d\n" +
+		"worker   \x1b[31mCrashLoopBackOff\x1b[0m   5m\n" +
+		"init     \x1b[33mInit:0/1\x1b[0m           1m\n" +
+		"svc      <none>             7d\n"
+
+	tests := map[string]struct {
+		noHeaders bool
+		expected  string
+	}{
+		"with headers": {
+			noHeaders: false,
+			expected:  "NAME     STATUS             AGE\n" + rows,
+		},
+		"without headers": {
+			noHeaders: true,
+			expected:  rows,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			printer, err := cmd.CreateColorPrinter("table", tc.noHeaders)
+			require.NoError(t, err)
+
+			var out bytes.Buffer
+			require.NoError(t, printer.PrintObj(table, &out))
+			assert.Equal(t, tc.expected, out.String())
+		})
+	}
+}
+
+func TestAlignedPrinter_PrintObj(t *testing.T) {
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Name", Type: "string"},
+			{Name: "STATUS", Type: "string"},
+			{Name: "NODE", Type: "string", Priority: 1},
+			{Name: "NOTE", Type: "string"},
+		},
+		Rows: []metav1.TableRow{
+			{Cells: []any{"web", "Running", "worker-1", "first line\nsecond line"}},
+			{Cells: []any{"worker-2", "CrashLoopBackOff", nil, 3}},
+			{Cells: []any{"short"}},
+		},
+	}
+
+	tests := map[string]struct {
+		format    string
+		noHeaders bool
+		color     bool
+		expected  string
+	}{
+		"table": {
+			format: "table",
+			expected: "NAME       STATUS             NOTE\n" +
+				"web        Running            first line...\n" +
+				"worker-2   CrashLoopBackOff   3\n" +
+				"short                         \n",
+		},
+		"without headers": {
+			format:    "table",
+			noHeaders: true,
+			expected: "web        Running            first line...\n" +
+				"worker-2   CrashLoopBackOff   3\n" +
+				"short                         \n",
+		},
+		"wide": {
+			format: "wide",
+			expected: "NAME       STATUS             NODE       NOTE\n" +
+				"web        Running            worker-1   first line...\n" +
+				"worker-2   CrashLoopBackOff              3\n" +
+				"short                                    \n",
+		},
+		"color": {
+			format:    "table",
+			noHeaders: true,
+			color:     true,
+			expected: "web        \x1b[32mRunning\x1b[0m            first line...\n" +
+				"worker-2   \x1b[31mCrashLoopBackOff\x1b[0m   3\n" +
+				"short                         \n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			printer, err := cmd.CreateAlignedPrinter(tc.format, tc.noHeaders, tc.color)
+			require.NoError(t, err)
+
+			var out bytes.Buffer
+			require.NoError(t, printer.PrintObj(table, &out))
+			assert.Equal(t, tc.expected, out.String())
+		})
+	}
+
+	printer, err := cmd.CreateAlignedPrinter("table", false, false)
+	require.NoError(t, err)
+	var out bytes.Buffer
+	require.NoError(t, printer.PrintObj(&metav1.Table{ColumnDefinitions: table.ColumnDefinitions}, &out))
+	assert.Empty(t, out.String())
+	assert.ErrorIs(t, printer.PrintObj(&corev1.PodList{}, &out), cmd.ErrExpectedTable)
+}
+
+func TestTruncatingPrinter_PrintObj(t *testing.T) {
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "NAME", Type: "string"},
+			{Name: "LABELS", Type: "string"},
+		},
+		Rows: []metav1.TableRow{
+			{Cells: []any{"web-1", "app=web,pod-template-hash=7c9f"}},
+			{Cells: []any{"web", "app=web"}},
+			{Cells: []any{"restarts", 12345678}},
+		},
+	}
+
+	tests := map[string]struct {
+		maxWidth int
+		expected string
+	}{
+		"ellipsis": {
+			maxWidth: 10,
+			expected: "NAME       LABELS\n" +
+				"web-1      app=web...\n" +
+				"web        app=web\n" +
+				"restarts   12345678\n",
+		},
+		"narrower than the ellipsis": {
+			maxWidth: 2,
+			expected: "NAME   LABELS\n" +
+				"we     ap\n" +
+				"we     ap\n" +
+				"re     12345678\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			printer, err := cmd.CreateTruncatingPrinter("table", tc.maxWidth)
+			require.NoError(t, err)
+
+			var out bytes.Buffer
+			require.NoError(t, printer.PrintObj(table, &out))
+			assert.Equal(t, tc.expected, out.String())
+		})
+	}
+
+	assert.Equal(t, "app=web,pod-template-hash=7c9f", table.Rows[0].Cells[1], "the printed table is left untouched")
+
+	printer, err := cmd.CreateTruncatingPrinter("json", 10)
+	require.NoError(t, err)
+	var out bytes.Buffer
+	require.NoError(t, printer.PrintObj(table, &out))
+	assert.Contains(t, out.String(), "app=web,pod-template-hash=7c9f")
+}
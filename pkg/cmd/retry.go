@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// defaultRetryDelay is the wait before the first retry, doubled for every following one.
+const defaultRetryDelay = 500 * time.Millisecond
+
+// withRetries runs the request, repeating it up to --retries times with exponential backoff while it fails with
+// a transient error. Other errors, and the last transient one, are returned as is.
+func (o *IPsOptions) withRetries(ctx context.Context, request func() error) error {
+	backoff := wait.Backoff{Duration: o.retryDelay, Factor: 2, Jitter: 0.1, Steps: o.retries}
+
+	for attempt := 1; ; attempt++ {
+		err := request()
+		if err == nil || attempt > o.retries || !isTransient(err) {
+			return err
+		}
+
+		delay := backoff.Step()
+		_, _ = fmt.Fprintf(o.ErrOut, "Retrying in %s (%d of %d): %v\n",
+			delay.Round(time.Millisecond), attempt, o.retries, err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// isTransient reports whether the API request failed for a reason that is likely gone on the next attempt, such
+// as throttling, an overloaded API server or a dropped connection.
+func isTransient(err error) bool {
+	return apierrors.IsTooManyRequests(err) ||
+		apierrors.IsServiceUnavailable(err) ||
+		apierrors.IsServerTimeout(err) ||
+		apierrors.IsTimeout(err) ||
+		utilnet.IsConnectionReset(err) ||
+		utilnet.IsHTTP2ConnectionLost(err) ||
+		utilnet.IsProbableEOF(err)
+}
@@ -0,0 +1,2279 @@
+package cmd_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andreygrechin/kubectl-ips/pkg/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func newRunTestPod(namespace, name string, phase corev1.PodPhase, labels map[string]string, ips ...string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+		Status:     corev1.PodStatus{Phase: phase},
+	}
+	if len(ips) > 0 {
+		pod.Status.PodIP = ips[0]
+	}
+	for _, ip := range ips {
+		pod.Status.PodIPs = append(pod.Status.PodIPs, corev1.PodIP{IP: ip})
+	}
+
+	return pod
+}
+
+// newRunTestClientset seeds a fake cluster with pods in mixed phases, dual-stack and missing IPs,
+// and two host-network pods sharing their node IP.
+func newRunTestClientset() *fake.Clientset {
+	web := map[string]string{"app": "web"}
+	proxy := newRunTestPod("kube-system", "kube-proxy", corev1.PodRunning, nil, "192.168.1.11")
+	proxy.Spec.HostNetwork = true
+	exporter := newRunTestPod("kube-system", "node-exporter", corev1.PodRunning, nil, "192.168.1.11")
+	exporter.Spec.HostNetwork = true
+
+	return fake.NewClientset(
+		newRunTestPod("default", "web-2", corev1.PodRunning, web, "10.244.1.30"),
+		newRunTestPod("default", "web-1", corev1.PodRunning, web, "10.244.1.5", "fd00::5"),
+		newRunTestPod("default", "starting", corev1.PodPending, web),
+		newRunTestPod("default", "batch", corev1.PodFailed, map[string]string{"app": "batch"}, "10.244.1.9"),
+		newRunTestPod("kube-system", "coredns", corev1.PodRunning, map[string]string{"app": "dns"}, "10.244.0.2"),
+		proxy,
+		exporter,
+	)
+}
+
+func runWithFakeClientset(t *testing.T, setup func(o *cmd.IPsOptions)) (string, string) {
+	t.Helper()
+
+	streams, _, out, errOut := genericiooptions.NewTestIOStreams()
+	options := cmd.NewIPsOptions(streams)
+	options.SetClientset(newRunTestClientset())
+	options.SetNamespace("default")
+	options.SetOutputFormat("table")
+	setup(options)
+	require.NoError(t, options.Validate())
+
+	require.NoError(t, options.Run(context.Background()))
+
+	return out.String(), errOut.String()
+}
+
+func TestRun_tableOutput(t *testing.T) {
+	tests := map[string]struct {
+		setup          func(o *cmd.IPsOptions)
+		expected       string
+		expectedErrOut string
+	}{
+		"namespace": {
+			setup: func(*cmd.IPsOptions) {},
+			expected: "NAME    IP            STATUS    AGE\n" +
+				"batch   10.244.1.9    Failed    <unknown>\n" +
+				"web-1   10.244.1.5    Running   <unknown>\n" +
+				"web-1   fd00::5       Running   <unknown>\n" +
+				"web-2   10.244.1.30   Running   <unknown>\n",
+		},
+		"label selector": {
+			setup: func(o *cmd.IPsOptions) { o.SetLabelSelector("app=web") },
+			expected: "NAME    IP            STATUS    AGE\n" +
+				"web-1   10.244.1.5    Running   <unknown>\n" +
+				"web-1   fd00::5       Running   <unknown>\n" +
+				"web-2   10.244.1.30   Running   <unknown>\n",
+		},
+		"set-based label selector": {
+			setup: func(o *cmd.IPsOptions) {
+				o.SetAllNamespaces(true)
+				o.SetLabelSelector("app in (batch,dns)")
+			},
+			expected: "NAMESPACE     NAME      IP           STATUS    AGE\n" +
+				"default       batch     10.244.1.9   Failed    <unknown>\n" +
+				"kube-system   coredns   10.244.0.2   Running   <unknown>\n",
+		},
+		"label columns": {
+			setup: func(o *cmd.IPsOptions) {
+				o.SetAllNamespaces(true)
+				o.SetLabelColumns([]string{"app", "app.kubernetes.io/version"})
+				o.SetHostNetworkFilters(true, false)
+			},
+			expected: "NAMESPACE     NAME      IP            STATUS    AGE         APP     APP.KUBERNETES.IO/VERSION\n" +
+				"default       batch     10.244.1.9    Failed    <unknown>   batch   <none>\n" +
+				"default       web-1     10.244.1.5    Running   <unknown>   web     <none>\n" +
+				"default       web-1     fd00::5       Running   <unknown>   web     <none>\n" +
+				"default       web-2     10.244.1.30   Running   <unknown>   web     <none>\n" +
+				"kube-system   coredns   10.244.0.2    Running   <unknown>   dns     <none>\n",
+		},
+		"dns column": {
+			setup: func(o *cmd.IPsOptions) {
+				o.SetLabelSelector("app=web")
+				o.SetShowDNS(true, "example.internal.")
+			},
+			expected: "NAME    IP            STATUS    AGE         DNS\n" +
+				"web-1   10.244.1.5    Running   <unknown>   10-244-1-5.default.pod.example.internal\n" +
+				"web-1   fd00::5       Running   <unknown>   fd00--5.default.pod.example.internal\n" +
+				"web-2   10.244.1.30   Running   <unknown>   10-244-1-30.default.pod.example.internal\n",
+		},
+		"wide": {
+			setup: func(o *cmd.IPsOptions) {
+				o.SetLabelSelector("app=web")
+				o.SetOutputFormat("wide")
+			},
+			expected: "NAME    IP            STATUS    READY   RESTARTS   NODE     HOST-IP   OWNER    " +
+				"QOS         PRIORITY-CLASS   FAMILY   AGE\n" +
+				"web-1   10.244.1.5    Running   0/0     0          <none>   <none>    <none>   " +
+				"<unknown>   <none>           IPv4     <unknown>\n" +
+				"web-1   fd00::5       Running   0/0     0          <none>   <none>    <none>   " +
+				"<unknown>   <none>           IPv6     <unknown>\n" +
+				"web-2   10.244.1.30   Running   0/0     0          <none>   <none>    <none>   " +
+				"<unknown>   <none>           IPv4     <unknown>\n",
+		},
+		"lower header style": {
+			setup: func(o *cmd.IPsOptions) {
+				o.SetLabelSelector("app=web")
+				o.SetHeaderStyle("lower")
+			},
+			expected: "name    ip            status    age\n" +
+				"web-1   10.244.1.5    Running   <unknown>\n" +
+				"web-1   fd00::5       Running   <unknown>\n" +
+				"web-2   10.244.1.30   Running   <unknown>\n",
+		},
+		"title header style": {
+			setup: func(o *cmd.IPsOptions) {
+				o.SetLabelSelector("app=batch")
+				o.SetHeaderStyle("title")
+				o.SetOutputFormat("wide")
+			},
+			expected: "Name    Ip           Status   Ready   Restarts   Node     Host-Ip   Owner    Qos         " +
+				"Priority-Class   Family   Age\n" +
+				"batch   10.244.1.9   Failed   0/0     0          <none>   <none>    <none>   <unknown>   " +
+				"<none>           IPv4     <unknown>\n",
+		},
+		"title header style csv": {
+			setup: func(o *cmd.IPsOptions) {
+				o.SetLabelSelector("app=batch")
+				o.SetHeaderStyle("title")
+				o.SetDualStack(true)
+				o.SetOutputFormat("csv")
+			},
+			expected: "Name,Ipv4,Ipv6,Status,Age\n" +
+				"batch,10.244.1.9,<none>,Failed,<unknown>\n",
+		},
+		"dual-stack": {
+			setup: func(o *cmd.IPsOptions) {
+				o.SetLabelSelector("app=web")
+				o.SetShowPending(true)
+				o.SetDualStack(true)
+				o.SetOutputFormat("wide")
+			},
+			expected: "NAME       IPV4          IPV6      STATUS    READY   RESTARTS   NODE     HOST-IP   OWNER    " +
+				"QOS         PRIORITY-CLASS   AGE\n" +
+				"starting   <none>        <none>    Pending   0/0     0          <none>   <none>    <none>   " +
+				"<unknown>   <none>           <unknown>\n" +
+				"web-1      10.244.1.5    fd00::5   Running   0/0     0          <none>   <none>    <none>   " +
+				"<unknown>   <none>           <unknown>\n" +
+				"web-2      10.244.1.30   <none>    Running   0/0     0          <none>   <none>    <none>   " +
+				"<unknown>   <none>           <unknown>\n",
+		},
+		"show pending": {
+			setup: func(o *cmd.IPsOptions) {
+				o.SetLabelSelector("app=web")
+				o.SetShowPending(true)
+			},
+			expected: "NAME       IP            STATUS    AGE\n" +
+				"starting   <none>        Pending   <unknown>\n" +
+				"web-1      10.244.1.5    Running   <unknown>\n" +
+				"web-1      fd00::5       Running   <unknown>\n" +
+				"web-2      10.244.1.30   Running   <unknown>\n",
+		},
+		"sorted by ip": {
+			setup: func(o *cmd.IPsOptions) { o.SetSortBy("ip") },
+			expected: "NAME    IP            STATUS    AGE\n" +
+				"web-1   10.244.1.5    Running   <unknown>\n" +
+				"batch   10.244.1.9    Failed    <unknown>\n" +
+				"web-2   10.244.1.30   Running   <unknown>\n" +
+				"web-1   fd00::5       Running   <unknown>\n",
+		},
+		"expanded ipv6": {
+			setup: func(o *cmd.IPsOptions) {
+				o.SetLabelSelector("app=web")
+				o.SetExpandIPv6(true)
+			},
+			expected: "NAME    IP                                        STATUS    AGE\n" +
+				"web-1   10.244.1.5                                Running   <unknown>\n" +
+				"web-1   fd00:0000:0000:0000:0000:0000:0000:0005   Running   <unknown>\n" +
+				"web-2   10.244.1.30                               Running   <unknown>\n",
+		},
+		"expanded ipv6 only": {
+			setup: func(o *cmd.IPsOptions) {
+				o.SetLabelSelector("app=web")
+				o.SetShowIPsOnly(true)
+				o.SetExpandIPv6(true)
+			},
+			expected: "10.244.1.5\nfd00:0000:0000:0000:0000:0000:0000:0005\n10.244.1.30\n",
+		},
+		"ips only with comma delimiter": {
+			setup: func(o *cmd.IPsOptions) {
+				o.SetLabelSelector("app=web")
+				o.SetShowIPsOnly(true)
+				o.SetIPsDelimiter(",")
+			},
+			expected: "10.244.1.5,fd00::5,10.244.1.30\n",
+		},
+		"ips only with escaped tab delimiter": {
+			setup: func(o *cmd.IPsOptions) {
+				o.SetLabelSelector("app=web")
+				o.SetShowIPsOnly(true)
+				o.SetIPsDelimiter(`\t`)
+			},
+			expected: "10.244.1.5\tfd00::5\t10.244.1.30\n",
+		},
+		"raw ips": {
+			setup: func(o *cmd.IPsOptions) {
+				o.SetLabelSelector("app=web")
+				o.SetShowPending(true)
+				o.SetRawIPs(true)
+			},
+			expected: "10.244.1.5 fd00::5 10.244.1.30\n",
+		},
+		"raw ips without matches": {
+			setup: func(o *cmd.IPsOptions) {
+				o.SetLabelSelector("app=missing")
+				o.SetRawIPs(true)
+			},
+			expected:       "",
+			expectedErrOut: "No pods found in default matching selector \"app=missing\"\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			out, errOut := runWithFakeClientset(t, tc.setup)
+			assert.Equal(t, tc.expected, out)
+			assert.Equal(t, tc.expectedErrOut, errOut)
+		})
+	}
+}
+
+func TestRun_dualStackMaxPods(t *testing.T) {
+	out, errOut := runWithFakeClientset(t, func(o *cmd.IPsOptions) {
+		o.SetDualStack(true)
+		o.SetSortBy("ip")
+		o.SetMaxPods(2)
+	})
+	assert.Equal(t, "NAME    IPV4         IPV6      STATUS    AGE\n"+
+		"web-1   10.244.1.5   fd00::5   Running   <unknown>\n"+
+		"batch   10.244.1.9   <none>    Failed    <unknown>\n", out)
+	assert.Equal(t, "... (showing 2 of 3)\n", errOut)
+}
+
+func TestRun_retries(t *testing.T) {
+	tooManyRequests := apierrors.NewTooManyRequests("slow down", 1)
+	forbidden := apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", errors.New("denied"))
+
+	tests := map[string]struct {
+		failures    []error
+		retries     int
+		expectedErr error
+		calls       int
+		retryLines  int
+	}{
+		"transient errors then success": {
+			failures:   []error{tooManyRequests, apierrors.NewServiceUnavailable("restarting")},
+			retries:    3,
+			calls:      3,
+			retryLines: 2,
+		},
+		"retries exhausted": {
+			failures:    []error{tooManyRequests, tooManyRequests, tooManyRequests},
+			retries:     2,
+			expectedErr: tooManyRequests,
+			calls:       3,
+			retryLines:  2,
+		},
+		"not retryable": {
+			failures:    []error{forbidden},
+			retries:     3,
+			expectedErr: forbidden,
+			calls:       1,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			clientset := newRunTestClientset()
+			calls := 0
+			clientset.PrependReactor("list", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+				calls++
+				if calls <= len(tc.failures) {
+					return true, nil, tc.failures[calls-1]
+				}
+
+				return false, nil, nil
+			})
+
+			streams, _, out, errOut := genericiooptions.NewTestIOStreams()
+			options := cmd.NewIPsOptions(streams)
+			options.SetClientset(clientset)
+			options.SetNamespace("default")
+			options.SetOutputFormat("name")
+			options.SetRetries(tc.retries)
+			options.SetRetryDelay(time.Millisecond)
+			require.NoError(t, options.Validate())
+
+			err := options.Run(context.Background())
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				assert.Empty(t, out.String())
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "batch\nweb-1\nweb-1\nweb-2\n", out.String())
+			}
+			assert.Equal(t, tc.calls, calls)
+			assert.Equal(t, tc.retryLines, strings.Count(errOut.String(), "Retrying in "))
+		})
+	}
+}
+
+func TestRun_filename(t *testing.T) {
+	const podList = `apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: web-1
+    namespace: default
+    labels:
+      app: web
+  status:
+    phase: Running
+    podIP: 10.244.1.2
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: coredns
+    namespace: kube-system
+  status:
+    phase: Running
+    podIP: 10.244.0.2
+`
+	const podStream = `apiVersion: v1
+kind: Pod
+metadata:
+  name: web-1
+  namespace: default
+status:
+  phase: Running
+  podIP: 10.244.1.2
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: default
+spec:
+  clusterIP: 10.96.0.10
+---
+{"apiVersion": "v1", "kind": "PodList", "items": [
+  {"metadata": {"name": "web-2", "namespace": "default"}, "status": {"phase": "Running", "podIP": "10.244.2.3"}}
+]}
+`
+
+	tests := map[string]struct {
+		input         string
+		allNamespaces bool
+		labelSelector string
+		podNames      []string
+		expectedOut   string
+		expectedErr   string
+	}{
+		"list in the namespace": {
+			input:       podList,
+			expectedOut: "10.244.1.2\n",
+		},
+		"list in all namespaces": {
+			input:         podList,
+			allNamespaces: true,
+			expectedOut:   "10.244.1.2\n10.244.0.2\n",
+		},
+		"label selector": {
+			input:         podList,
+			allNamespaces: true,
+			labelSelector: "app=web",
+			expectedOut:   "10.244.1.2\n",
+		},
+		"stream skipping other kinds": {
+			input:       podStream,
+			expectedOut: "10.244.1.2\n10.244.2.3\n",
+		},
+		"pod names": {
+			input:       podStream,
+			podNames:    []string{"web-2"},
+			expectedOut: "10.244.2.3\n",
+		},
+		"malformed": {
+			input:       "kind: Pod\nmetadata: [",
+			expectedErr: "failed to read pods from -",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams, in, out, _ := genericiooptions.NewTestIOStreams()
+			in.WriteString(tc.input)
+			options := cmd.NewIPsOptions(streams)
+			options.SetFilename("-")
+			if tc.allNamespaces {
+				options.SetAllNamespaces(true)
+			} else {
+				options.SetNamespace("default")
+			}
+			options.SetLabelSelector(tc.labelSelector)
+			options.SetPodNames(tc.podNames)
+			options.SetShowIPsOnly(true)
+			require.NoError(t, options.Validate())
+
+			err := options.Run(context.Background())
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedOut, out.String())
+		})
+	}
+}
+
+func TestRun_filenameFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pods.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"apiVersion": "v1", "kind": "Pod", `+
+		`"metadata": {"name": "web-1", "namespace": "default"}, "status": {"phase": "Running", "podIP": "10.244.1.2"}}`),
+		0o600))
+
+	streams, _, out, _ := genericiooptions.NewTestIOStreams()
+	options := cmd.NewIPsOptions(streams)
+	options.SetFilename(path)
+	options.SetNamespace("default")
+	options.SetOutputFormat("name")
+	require.NoError(t, options.Validate())
+
+	require.NoError(t, options.Run(context.Background()))
+	assert.Equal(t, "web-1\n", out.String())
+
+	options.SetFilename(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, options.Run(context.Background()), "failed to open pods file")
+}
+
+func TestRun_wideIPv6(t *testing.T) {
+	web1 := newRunTestPod("default", "web-1", corev1.PodRunning, nil, "10.244.1.5", "fd00::5")
+	web1.Status.HostIP = "192.168.1.10"
+	web2 := newRunTestPod("default", "web-2", corev1.PodRunning, nil, "10.244.1.30")
+	web2.Status.HostIP = "192.168.1.11"
+	pending := newRunTestPod("default", "starting", corev1.PodPending, nil)
+	// an IPv6-primary pod, whose IPv4 address sorts first
+	probe := newRunTestPod("default", "probe", corev1.PodRunning, nil, "fd00::7", "10.244.1.7")
+	probe.Status.HostIP = "192.168.1.10"
+
+	tests := map[string]struct {
+		setup    func(o *cmd.IPsOptions)
+		expected string
+	}{
+		"row per pod": {
+			setup: func(o *cmd.IPsOptions) { o.SetShowPending(true) },
+			expected: "NAME       IP            SECONDARY-IPS   STATUS    READY   RESTARTS   NODE     HOST-IP        " +
+				"OWNER    QOS         PRIORITY-CLASS   FAMILY   AGE\n" +
+				"probe      fd00::7       10.244.1.7      Running   0/0     0          <none>   192.168.1.10   " +
+				"<none>   <unknown>   <none>           IPv6     <unknown>\n" +
+				"starting   <none>        <none>          Pending   0/0     0          <none>   <none>         " +
+				"<none>   <unknown>   <none>           <none>   <unknown>\n" +
+				"web-1      10.244.1.5    fd00::5         Running   0/0     0          <none>   192.168.1.10   " +
+				"<none>   <unknown>   <none>           IPv4     <unknown>\n" +
+				"web-2      10.244.1.30   <none>          Running   0/0     0          <none>   192.168.1.11   " +
+				"<none>   <unknown>   <none>           IPv4     <unknown>\n",
+		},
+		"reversed": {
+			setup: func(o *cmd.IPsOptions) { o.SetReverse(true) },
+			expected: "NAME    IP            SECONDARY-IPS   STATUS    READY   RESTARTS   NODE     HOST-IP        " +
+				"OWNER    QOS         PRIORITY-CLASS   FAMILY   AGE\n" +
+				"web-2   10.244.1.30   <none>          Running   0/0     0          <none>   192.168.1.11   " +
+				"<none>   <unknown>   <none>           IPv4     <unknown>\n" +
+				"web-1   10.244.1.5    fd00::5         Running   0/0     0          <none>   192.168.1.10   " +
+				"<none>   <unknown>   <none>           IPv4     <unknown>\n" +
+				"probe   fd00::7       10.244.1.7      Running   0/0     0          <none>   192.168.1.10   " +
+				"<none>   <unknown>   <none>           IPv6     <unknown>\n",
+		},
+		"filtered family": {
+			setup: func(o *cmd.IPsOptions) { o.SetIPFamily("ipv4") },
+			expected: "NAME    IP            SECONDARY-IPS   STATUS    READY   RESTARTS   NODE     HOST-IP        " +
+				"OWNER    QOS         PRIORITY-CLASS   FAMILY   AGE\n" +
+				"probe   10.244.1.7    <none>          Running   0/0     0          <none>   192.168.1.10   " +
+				"<none>   <unknown>   <none>           IPv4     <unknown>\n" +
+				"web-1   10.244.1.5    <none>          Running   0/0     0          <none>   192.168.1.10   " +
+				"<none>   <unknown>   <none>           IPv4     <unknown>\n" +
+				"web-2   10.244.1.30   <none>          Running   0/0     0          <none>   192.168.1.11   " +
+				"<none>   <unknown>   <none>           IPv4     <unknown>\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, errOut := genericiooptions.NewTestIOStreams()
+			options := cmd.NewIPsOptions(streams)
+			options.SetClientset(fake.NewClientset(web1, web2, pending, probe))
+			options.SetNamespace("default")
+			options.SetOutputFormat("wide-ipv6")
+			tc.setup(options)
+			require.NoError(t, options.Validate())
+
+			require.NoError(t, options.Run(context.Background()))
+			assert.Equal(t, tc.expected, out.String())
+			assert.Empty(t, errOut.String())
+		})
+	}
+
+	options := cmd.NewIPsOptions(genericiooptions.NewTestIOStreamsDiscard())
+	options.SetOutputFormat("wide-ipv6")
+	options.SetDualStack(true)
+	assert.ErrorIs(t, options.Validate(), cmd.ErrIncompatibleFlags)
+}
+
+func TestRun_selectorFile(t *testing.T) {
+	selectorFile := filepath.Join(t.TempDir(), "selector.txt")
+	require.NoError(t, os.WriteFile(selectorFile, []byte("\n  app in (web, dns),\n!missing  \n"), 0o600))
+
+	streams, _, out, errOut := genericiooptions.NewTestIOStreams()
+	command := cmd.NewCmdIPs(streams)
+	require.NoError(t, command.Flags().Set("namespace", "default"))
+	options := cmd.NewIPsOptions(streams)
+	options.SetSelectorFile(selectorFile)
+	options.SetOutputFormat("name")
+	require.NoError(t, options.Complete(command, nil))
+	options.SetClientset(newRunTestClientset())
+	require.NoError(t, options.Validate())
+
+	require.NoError(t, options.Run(context.Background()))
+	assert.Equal(t, "web-1\nweb-1\nweb-2\n", out.String())
+	assert.Empty(t, errOut.String())
+}
+
+func TestRun_repeatedSelectors(t *testing.T) {
+	tests := map[string]struct {
+		selectors []string
+		expected  string
+	}{
+		"single selector": {
+			selectors: []string{"app in (web,batch)"},
+			expected:  "batch\nweb-1\nweb-1\nweb-2\n",
+		},
+		"all selectors match": {
+			selectors: []string{"app in (web,batch)", "app!=batch"},
+			expected:  "web-1\nweb-1\nweb-2\n",
+		},
+		"empty selector": {
+			selectors: []string{"app=batch", ""},
+			expected:  "batch\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, _ := genericiooptions.NewTestIOStreams()
+			command := cmd.NewCmdIPs(streams)
+			require.NoError(t, command.Flags().Set("namespace", "default"))
+			for _, selector := range tc.selectors {
+				require.NoError(t, command.Flags().Set("selector", selector))
+			}
+			options := cmd.NewIPsOptions(streams)
+			options.SetOutputFormat("name")
+			require.NoError(t, options.Complete(command, nil))
+			options.SetClientset(newRunTestClientset())
+			require.NoError(t, options.Validate())
+
+			require.NoError(t, options.Run(context.Background()))
+			assert.Equal(t, tc.expected, out.String())
+		})
+	}
+}
+
+func TestRun_podNameRegex(t *testing.T) {
+	tests := map[string]struct {
+		setup    func(o *cmd.IPsOptions)
+		expected string
+	}{
+		"anchored": {
+			setup:    func(o *cmd.IPsOptions) { o.SetPodNameRegex(`^web-\d+$`) },
+			expected: "web-1\nweb-1\nweb-2\n",
+		},
+		"unanchored": {
+			setup:    func(o *cmd.IPsOptions) { o.SetPodNameRegex("2") },
+			expected: "web-2\n",
+		},
+		"all namespaces": {
+			setup: func(o *cmd.IPsOptions) {
+				o.SetAllNamespaces(true)
+				o.SetPodNameRegex("^(core|kube-)")
+			},
+			expected: "kube-system/coredns\nkube-system/kube-proxy\n",
+		},
+		"exclude": {
+			setup:    func(o *cmd.IPsOptions) { o.SetExcludePodRegex("^web-") },
+			expected: "batch\n",
+		},
+		"include then exclude": {
+			setup: func(o *cmd.IPsOptions) {
+				o.SetPodNameRegex(`^web-\d+$`)
+				o.SetExcludePodRegex("1$")
+			},
+			expected: "web-2\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			out, _ := runWithFakeClientset(t, func(o *cmd.IPsOptions) {
+				o.SetOutputFormat("name")
+				tc.setup(o)
+			})
+			assert.Equal(t, tc.expected, out)
+		})
+	}
+}
+
+func TestRun_alignedColumns(t *testing.T) {
+	stock, _ := runWithFakeClientset(t, func(o *cmd.IPsOptions) { o.SetAllNamespaces(true) })
+	aligned, _ := runWithFakeClientset(t, func(o *cmd.IPsOptions) {
+		o.SetAllNamespaces(true)
+		o.SetAlignedColumns(true)
+	})
+	assert.Equal(t, stock, aligned)
+}
+
+func TestRun_showAgeTimestamp(t *testing.T) {
+	created := newRunTestPod("default", "web-1", corev1.PodRunning, nil, "10.244.1.5")
+	created.CreationTimestamp = metav1.NewTime(time.Date(2024, 3, 1, 14, 30, 0, 0, time.FixedZone("CET", 3600)))
+	unknown := newRunTestPod("default", "web-2", corev1.PodRunning, nil, "10.244.1.6")
+
+	tests := map[string]struct {
+		timezone string
+		expected string
+	}{
+		"utc": {
+			timezone: "UTC",
+			expected: "NAME    IP           STATUS    AGE\n" +
+				"web-1   10.244.1.5   Running   2024-03-01T13:30:00Z\n" +
+				"web-2   10.244.1.6   Running   <unknown>\n",
+		},
+		"iana name": {
+			timezone: "Asia/Tokyo",
+			expected: "NAME    IP           STATUS    AGE\n" +
+				"web-1   10.244.1.5   Running   2024-03-01T22:30:00+09:00\n" +
+				"web-2   10.244.1.6   Running   <unknown>\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, errOut := genericiooptions.NewTestIOStreams()
+			options := cmd.NewIPsOptions(streams)
+			options.SetClientset(fake.NewClientset(created, unknown))
+			options.SetNamespace("default")
+			options.SetShowAgeTimestamp(true)
+			options.SetTimezone(tc.timezone)
+			require.NoError(t, options.Validate())
+
+			require.NoError(t, options.Run(context.Background()))
+			assert.Equal(t, tc.expected, out.String())
+			assert.Empty(t, errOut.String())
+		})
+	}
+}
+
+func TestRun_showImages(t *testing.T) {
+	web := newRunTestPod("default", "web-1", corev1.PodRunning, nil, "10.244.1.5")
+	web.Spec.Containers = []corev1.Container{
+		{Name: "web", Image: "nginx:1.27"},
+		{Name: "proxy", Image: "envoyproxy/envoy:v1.31"},
+	}
+	bare := newRunTestPod("default", "bare", corev1.PodRunning, nil, "10.244.1.6")
+
+	tests := map[string]struct {
+		containers bool
+		expected   string
+	}{
+		"pods": {
+			expected: "NAME    IP           STATUS    AGE         IMAGES\n" +
+				"bare    10.244.1.6   Running   <unknown>   <none>\n" +
+				"web-1   10.244.1.5   Running   <unknown>   nginx:1.27,envoyproxy/envoy:v1.31\n",
+		},
+		"containers": {
+			containers: true,
+			expected: "NAME    CONTAINER   IP           STATUS    READY   RESTARTS   AGE         IMAGES\n" +
+				"bare    <none>      10.244.1.6   Running   0/0     0          <unknown>   <none>\n" +
+				"web-1   web         10.244.1.5   Running   0/1     0          <unknown>   nginx:1.27\n" +
+				"web-1   proxy       10.244.1.5   Running   0/1     0          <unknown>   envoyproxy/envoy:v1.31\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, _ := genericiooptions.NewTestIOStreams()
+			options := cmd.NewIPsOptions(streams)
+			options.SetClientset(fake.NewClientset(web, bare))
+			options.SetNamespace("default")
+			options.SetShowImages(true)
+			options.SetContainers(tc.containers)
+			require.NoError(t, options.Validate())
+
+			require.NoError(t, options.Run(context.Background()))
+			assert.Equal(t, tc.expected, out.String())
+		})
+	}
+}
+
+func TestRun_since(t *testing.T) {
+	newPod := func(name string, age time.Duration, ip string) *corev1.Pod {
+		pod := newRunTestPod("default", name, corev1.PodRunning, nil, ip)
+		pod.CreationTimestamp = metav1.NewTime(time.Now().Add(-age))
+
+		return pod
+	}
+	clientset := fake.NewClientset(
+		newPod("old", 2*time.Hour, "10.244.1.2"),
+		newPod("recent", 5*time.Minute, "10.244.1.3"),
+		newPod("new", time.Minute, "10.244.1.4"),
+	)
+
+	tests := map[string]struct {
+		since          time.Duration
+		olderThan      time.Duration
+		expectedOut    string
+		expectedErrOut string
+	}{
+		"any age": {
+			expectedOut: "new\nold\nrecent\n",
+		},
+		"last 10 minutes": {
+			since:       10 * time.Minute,
+			expectedOut: "new\nrecent\n",
+		},
+		"nothing that new": {
+			since:          30 * time.Second,
+			expectedErrOut: "No pods found in default created in the last 30s\n",
+		},
+		"older than an hour": {
+			olderThan:   time.Hour,
+			expectedOut: "old\n",
+		},
+		"between 2 and 10 minutes old": {
+			since:       10 * time.Minute,
+			olderThan:   2 * time.Minute,
+			expectedOut: "recent\n",
+		},
+		"nothing that old": {
+			olderThan:      30 * 24 * time.Hour,
+			expectedErrOut: "No pods found in default created more than 30d ago\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, errOut := genericiooptions.NewTestIOStreams()
+			options := cmd.NewIPsOptions(streams)
+			options.SetClientset(clientset)
+			options.SetNamespace("default")
+			options.SetOutputFormat("name")
+			options.SetSince(tc.since)
+			options.SetOlderThan(tc.olderThan)
+			require.NoError(t, options.Validate())
+
+			require.NoError(t, options.Run(context.Background()))
+			assert.Equal(t, tc.expectedOut, out.String())
+			assert.Equal(t, tc.expectedErrOut, errOut.String())
+		})
+	}
+}
+
+func TestRun_jsonIPsOutput(t *testing.T) {
+	out, _ := runWithFakeClientset(t, func(o *cmd.IPsOptions) {
+		o.SetLabelSelector("app=web")
+		o.SetOutputFormat("json-ips")
+		o.SetShowPending(true)
+		o.SetIncludeServices(true)
+	})
+
+	expected := `[
+  {
+    "namespace": "default",
+    "pod": "web-1",
+    "ip": "10.244.1.5",
+    "status": "Running"
+  },
+  {
+    "namespace": "default",
+    "pod": "web-1",
+    "ip": "fd00::5",
+    "status": "Running"
+  },
+  {
+    "namespace": "default",
+    "pod": "web-2",
+    "ip": "10.244.1.30",
+    "status": "Running"
+  }
+]
+`
+	assert.Equal(t, expected, out)
+}
+
+func TestRun_compactJSONIPsOutput(t *testing.T) {
+	out, _ := runWithFakeClientset(t, func(o *cmd.IPsOptions) {
+		o.SetLabelSelector("app=web")
+		o.SetOutputFormat("json-ips")
+		o.SetCompact(true)
+	})
+
+	expected := `[{"namespace":"default","pod":"web-1","ip":"10.244.1.5","status":"Running"},` +
+		`{"namespace":"default","pod":"web-1","ip":"fd00::5","status":"Running"},` +
+		`{"namespace":"default","pod":"web-2","ip":"10.244.1.30","status":"Running"}]` + "\n"
+	assert.Equal(t, expected, out)
+}
+
+func TestRun_jsonlOutput(t *testing.T) {
+	out, _ := runWithFakeClientset(t, func(o *cmd.IPsOptions) {
+		o.SetLabelSelector("app=web")
+		o.SetOutputFormat("jsonl")
+		o.SetShowPending(true)
+		o.SetIncludeServices(true)
+	})
+
+	expected := `{"namespace":"default","pod":"web-1","ip":"10.244.1.5","status":"Running"}
+{"namespace":"default","pod":"web-1","ip":"fd00::5","status":"Running"}
+{"namespace":"default","pod":"web-2","ip":"10.244.1.30","status":"Running"}
+`
+	assert.Equal(t, expected, out)
+}
+
+func TestRun_labelSelector(t *testing.T) {
+	tests := map[string]struct {
+		selector string
+		expected string
+	}{
+		"negation": {
+			selector: "app!=web",
+			expected: "default/batch\nkube-system/coredns\nkube-system/kube-proxy\nkube-system/node-exporter\n",
+		},
+		"in": {
+			selector: "app in (batch,dns)",
+			expected: "default/batch\nkube-system/coredns\n",
+		},
+		"notin": {
+			selector: "app notin (web,dns)",
+			expected: "default/batch\nkube-system/kube-proxy\nkube-system/node-exporter\n",
+		},
+		"exists": {
+			selector: "app",
+			expected: "default/batch\ndefault/web-1\ndefault/web-1\ndefault/web-2\nkube-system/coredns\n",
+		},
+		"does not exist": {
+			selector: "!app",
+			expected: "kube-system/kube-proxy\nkube-system/node-exporter\n",
+		},
+		"combined": {
+			selector: "app,app notin (batch),!tier",
+			expected: "default/web-1\ndefault/web-1\ndefault/web-2\nkube-system/coredns\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			out, errOut := runWithFakeClientset(t, func(o *cmd.IPsOptions) {
+				o.SetAllNamespaces(true)
+				o.SetLabelSelector(tc.selector)
+				o.SetNoDedup(true)
+				o.SetOutputFormat("name")
+			})
+			assert.Equal(t, tc.expected, out)
+			assert.Empty(t, errOut)
+		})
+	}
+}
+
+func TestRun_service(t *testing.T) {
+	newService := func(name string, selector map[string]string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       corev1.ServiceSpec{Selector: selector},
+		}
+	}
+	clientset := newRunTestClientset()
+	for _, service := range []*corev1.Service{
+		newService("web", map[string]string{"app": "web"}),
+		newService("external", nil),
+	} {
+		_, err := clientset.CoreV1().Services("default").Create(context.Background(), service, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	notReady := false
+	for _, slice := range []*discoveryv1.EndpointSlice{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "external-1",
+				Namespace: "default",
+				Labels:    map[string]string{discoveryv1.LabelServiceName: "external"},
+			},
+			AddressType: discoveryv1.AddressTypeIPv4,
+			Endpoints: []discoveryv1.Endpoint{
+				{Addresses: []string{"172.16.0.10"}},
+				{Addresses: []string{"172.16.0.11"}, Conditions: discoveryv1.EndpointConditions{Ready: &notReady}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "web-1",
+				Namespace: "default",
+				Labels:    map[string]string{discoveryv1.LabelServiceName: "web"},
+			},
+			AddressType: discoveryv1.AddressTypeIPv4,
+			Endpoints: []discoveryv1.Endpoint{
+				// pod-backed endpoints are listed with their pods
+				{Addresses: []string{"10.244.1.30"}, TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "web-2"}},
+				{Addresses: []string{"172.16.0.20"}},
+			},
+		},
+	} {
+		_, err := clientset.DiscoveryV1().EndpointSlices("default").Create(
+			context.Background(), slice, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	run := func(service string, setup ...func(o *cmd.IPsOptions)) (string, string, error) {
+		streams, _, out, errOut := genericiooptions.NewTestIOStreams()
+		options := cmd.NewIPsOptions(streams)
+		options.SetClientset(clientset)
+		options.SetNamespace("default")
+		options.SetOutputFormat("name")
+		options.SetService(service)
+		for _, f := range setup {
+			f(options)
+		}
+		require.NoError(t, options.Validate())
+		err := options.Run(context.Background())
+
+		return out.String(), errOut.String(), err
+	}
+
+	t.Run("selected pods", func(t *testing.T) {
+		out, errOut, err := run("web")
+		require.NoError(t, err)
+		assert.Equal(t, "web-1\nweb-1\nweb-2\n", out)
+		assert.Empty(t, errOut)
+	})
+
+	t.Run("without selector", func(t *testing.T) {
+		_, _, err := run("external")
+		require.ErrorIs(t, err, cmd.ErrServiceWithoutSelector)
+		assert.Contains(t, err.Error(), `service "external"`)
+	})
+
+	t.Run("endpoints without selector", func(t *testing.T) {
+		out, errOut, err := run("external", func(o *cmd.IPsOptions) {
+			o.SetIncludeEndpoints(true)
+			o.SetOutputFormat("csv")
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "NAME,IP,TYPE,STATUS,AGE\n"+
+			"external-1,172.16.0.10,Endpoint,Ready,<unknown>\n"+
+			"external-1,172.16.0.11,Endpoint,NotReady,<unknown>\n", out)
+		assert.Empty(t, errOut)
+	})
+
+	t.Run("endpoints with pods", func(t *testing.T) {
+		out, _, err := run("web", func(o *cmd.IPsOptions) {
+			o.SetIncludeEndpoints(true)
+			o.SetCount(true)
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Total: 4 IPs across 2 pods and 1 endpoint slice in 1 namespace\n", out)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, _, err := run("missing")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `failed to get service "missing"`)
+	})
+}
+
+func TestRun_selectorFromPod(t *testing.T) {
+	clientset := fake.NewClientset(
+		newRunTestPod("default", "web-1", corev1.PodRunning,
+			map[string]string{"app": "web", "tier": "frontend", "pod-template-hash": "5d59d67564"}, "10.244.1.5"),
+		// a replica of the previous revision differs only by its template hash
+		newRunTestPod("default", "web-2", corev1.PodRunning,
+			map[string]string{"app": "web", "tier": "frontend", "pod-template-hash": "7c9f8b6d4"}, "10.244.1.6"),
+		newRunTestPod("default", "api", corev1.PodRunning, map[string]string{"app": "api"}, "10.244.1.7"),
+		newRunTestPod("default", "hashed", corev1.PodRunning, map[string]string{"pod-template-hash": "5d59d67564"}),
+	)
+
+	run := func(pod string) (string, error) {
+		streams, _, out, _ := genericiooptions.NewTestIOStreams()
+		options := cmd.NewIPsOptions(streams)
+		options.SetClientset(clientset)
+		options.SetNamespace("default")
+		options.SetOutputFormat("name")
+		options.SetSelectorFromPod(pod)
+		require.NoError(t, options.Validate())
+		err := options.Run(context.Background())
+
+		return out.String(), err
+	}
+
+	t.Run("replicas", func(t *testing.T) {
+		out, err := run("web-1")
+		require.NoError(t, err)
+		assert.Equal(t, "web-1\nweb-2\n", out)
+	})
+
+	t.Run("without labels", func(t *testing.T) {
+		_, err := run("hashed")
+		require.ErrorIs(t, err, cmd.ErrPodWithoutLabels)
+		assert.Contains(t, err.Error(), `pod "hashed"`)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := run("missing")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `failed to get pod "missing"`)
+	})
+}
+
+func TestRun_nameOutput(t *testing.T) {
+	out, _ := runWithFakeClientset(t, func(o *cmd.IPsOptions) {
+		o.SetAllNamespaces(true)
+		o.SetOutputFormat("name")
+		o.SetHostNetworkFilters(true, false)
+	})
+
+	expected := "default/batch\n" +
+		"default/web-1\n" +
+		"default/web-1\n" +
+		"default/web-2\n" +
+		"kube-system/coredns\n"
+	assert.Equal(t, expected, out)
+}
+
+func TestRun_jsonOutput(t *testing.T) {
+	out, _ := runWithFakeClientset(t, func(o *cmd.IPsOptions) {
+		o.SetOutputFormat("json")
+		o.SetLabelSelector("app=web")
+	})
+
+	var table metav1.Table
+	require.NoError(t, json.Unmarshal([]byte(out), &table))
+
+	columns := make([]string, 0, len(table.ColumnDefinitions))
+	for _, column := range table.ColumnDefinitions {
+		columns = append(columns, column.Name)
+	}
+	assert.Equal(t, []string{"NAME", "IP", "STATUS", "AGE"}, columns)
+
+	rows := make([][]any, 0, len(table.Rows))
+	for _, row := range table.Rows {
+		rows = append(rows, row.Cells)
+		assert.NotEmpty(t, row.Object.Raw, "row should carry the pod")
+	}
+	assert.Equal(t, [][]any{
+		{"web-1", "10.244.1.5", "Running", "<unknown>"},
+		{"web-1", "fd00::5", "Running", "<unknown>"},
+		{"web-2", "10.244.1.30", "Running", "<unknown>"},
+	}, rows)
+
+	var pod corev1.Pod
+	require.NoError(t, json.Unmarshal(table.Rows[0].Object.Raw, &pod))
+	assert.Equal(t, "web-1", pod.Name)
+}
+
+func TestRun_compactJSONOutput(t *testing.T) {
+	out, _ := runWithFakeClientset(t, func(o *cmd.IPsOptions) {
+		o.SetOutputFormat("json")
+		o.SetLabelSelector("app=web")
+		o.SetCompact(true)
+	})
+
+	assert.Equal(t, 1, strings.Count(out, "\n"), "compact JSON should be a single line")
+	var table metav1.Table
+	require.NoError(t, json.Unmarshal([]byte(out), &table))
+	assert.Len(t, table.Rows, 3)
+}
+
+func TestRun_dedup(t *testing.T) {
+	tests := map[string]struct {
+		noDedup  bool
+		expected string
+	}{
+		"shared node IP listed once": {
+			noDedup:  false,
+			expected: "Total: 1 IP across 1 pod in 1 namespace\n",
+		},
+		"shared node IP listed per pod": {
+			noDedup:  true,
+			expected: "Total: 2 IPs across 2 pods in 1 namespace\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			out, _ := runWithFakeClientset(t, func(o *cmd.IPsOptions) {
+				o.SetNamespace("kube-system")
+				o.SetHostNetworkFilters(false, true)
+				o.SetNoDedup(tc.noDedup)
+				o.SetCount(true)
+			})
+			assert.Equal(t, tc.expected, out)
+		})
+	}
+}
+
+func TestRun_containers(t *testing.T) {
+	pod := newRunTestPod("default", "web-1", corev1.PodRunning, nil, "10.244.1.5")
+	pod.Spec.Containers = []corev1.Container{{Name: "app"}, {Name: "proxy"}}
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+		{Name: "app", Ready: true},
+		{Name: "proxy", Ready: false, RestartCount: 3},
+	}
+
+	tests := map[string]struct {
+		format   string
+		expected string
+	}{
+		"table": {
+			format: "table",
+			expected: "NAME    CONTAINER   IP           STATUS    READY   RESTARTS   AGE\n" +
+				"web-1   app         10.244.1.5   Running   1/1     0          <unknown>\n" +
+				"web-1   proxy       10.244.1.5   Running   0/1     3          <unknown>\n",
+		},
+		"name": {
+			format:   "name",
+			expected: "web-1\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, _ := genericiooptions.NewTestIOStreams()
+			options := cmd.NewIPsOptions(streams)
+			options.SetClientset(fake.NewClientset(pod))
+			options.SetNamespace("default")
+			options.SetOutputFormat(tc.format)
+			options.SetContainers(true)
+			require.NoError(t, options.Validate())
+			require.NoError(t, options.Run(context.Background()))
+
+			assert.Equal(t, tc.expected, out.String())
+		})
+	}
+}
+
+func TestRun_allInterfaces(t *testing.T) {
+	multus := newRunTestPod("default", "router", corev1.PodRunning, nil, "10.244.1.5")
+	multus.Annotations = map[string]string{"k8s.v1.cni.cncf.io/networks-status": `[
+		{"name": "cbr0", "interface": "eth0", "ips": ["10.244.1.5"], "default": true},
+		{"name": "default/storage", "interface": "net1", "ips": ["192.168.100.5"]}
+	]`}
+	malformed := newRunTestPod("default", "broken", corev1.PodRunning, nil, "10.244.1.6")
+	malformed.Annotations = map[string]string{"k8s.v1.cni.cncf.io/network-status": "not json"}
+	plain := newRunTestPod("default", "web", corev1.PodRunning, nil, "10.244.1.7")
+
+	streams, _, out, _ := genericiooptions.NewTestIOStreams()
+	options := cmd.NewIPsOptions(streams)
+	options.SetClientset(fake.NewClientset(multus, malformed, plain))
+	options.SetNamespace("default")
+	options.SetOutputFormat("table")
+	options.SetAllInterfaces(true)
+	require.NoError(t, options.Validate())
+	require.NoError(t, options.Run(context.Background()))
+
+	expected := "NAME     IP              INTERFACE   STATUS    AGE\n" +
+		"broken   10.244.1.6      <none>      Running   <unknown>\n" +
+		"router   10.244.1.5      eth0        Running   <unknown>\n" +
+		"router   192.168.100.5   net1        Running   <unknown>\n" +
+		"web      10.244.1.7      <none>      Running   <unknown>\n"
+	assert.Equal(t, expected, out.String())
+}
+
+func TestRun_annotationColumns(t *testing.T) {
+	owned := newRunTestPod("default", "payments", corev1.PodRunning, nil, "10.244.1.5")
+	owned.Annotations = map[string]string{"team.example.com/owner": "payments-team"}
+	unowned := newRunTestPod("default", "scratch", corev1.PodRunning, nil, "10.244.1.6")
+
+	streams, _, out, _ := genericiooptions.NewTestIOStreams()
+	options := cmd.NewIPsOptions(streams)
+	options.SetClientset(fake.NewClientset(owned, unowned))
+	options.SetNamespace("default")
+	options.SetOutputFormat("table")
+	options.SetAnnotationColumns([]string{"team.example.com/owner", "note"})
+	require.NoError(t, options.Validate())
+	require.NoError(t, options.Run(context.Background()))
+
+	expected := "NAME       IP           STATUS    AGE         TEAM.EXAMPLE.COM/OWNER   NOTE\n" +
+		"payments   10.244.1.5   Running   <unknown>   payments-team            <none>\n" +
+		"scratch    10.244.1.6   Running   <unknown>   <none>                   <none>\n"
+	assert.Equal(t, expected, out.String())
+}
+
+func TestRun_nodeLabelColumns(t *testing.T) {
+	zonal := newRunTestPod("default", "web-1", corev1.PodRunning, map[string]string{"app": "web"}, "10.244.1.5")
+	zonal.Spec.NodeName = "worker-1"
+	unlabeled := newRunTestPod("default", "web-2", corev1.PodRunning, map[string]string{"app": "web"}, "10.244.2.5")
+	unlabeled.Spec.NodeName = "worker-2"
+	pending := newRunTestPod("default", "web-3", corev1.PodPending, map[string]string{"app": "web"})
+	clientset := fake.NewClientset(zonal, unlabeled, pending,
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:   "worker-1",
+			Labels: map[string]string{"topology.kubernetes.io/zone": "eu-west-1a"},
+		}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-2"}},
+	)
+	nodeLists := 0
+	clientset.PrependReactor("list", "nodes", func(k8stesting.Action) (bool, runtime.Object, error) {
+		nodeLists++
+
+		return false, nil, nil
+	})
+
+	tests := map[string]struct {
+		format     string
+		showLabels bool
+		expected   string
+	}{
+		"table": {
+			format: "table",
+			expected: "NAME    IP           STATUS    AGE         TOPOLOGY.KUBERNETES.IO/ZONE\n" +
+				"web-1   10.244.1.5   Running   <unknown>   eu-west-1a\n" +
+				"web-2   10.244.2.5   Running   <unknown>   <none>\n" +
+				"web-3   <none>       Pending   <unknown>   <none>\n",
+		},
+		"before the labels column": {
+			format:     "csv",
+			showLabels: true,
+			expected: "NAME,IP,STATUS,AGE,TOPOLOGY.KUBERNETES.IO/ZONE,LABELS\n" +
+				"web-1,10.244.1.5,Running,<unknown>,eu-west-1a,app=web\n" +
+				"web-2,10.244.2.5,Running,<unknown>,<none>,app=web\n" +
+				"web-3,<none>,Pending,<unknown>,<none>,app=web\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			nodeLists = 0
+			streams, _, out, _ := genericiooptions.NewTestIOStreams()
+			options := cmd.NewIPsOptions(streams)
+			options.SetClientset(clientset)
+			options.SetNamespace("default")
+			options.SetOutputFormat(tc.format)
+			options.SetShowPending(true)
+			options.SetShowLabels(tc.showLabels)
+			options.SetNodeLabelColumns([]string{"topology.kubernetes.io/zone"})
+			require.NoError(t, options.Validate())
+
+			require.NoError(t, options.Run(context.Background()))
+			assert.Equal(t, tc.expected, out.String())
+			assert.Equal(t, 1, nodeLists)
+		})
+	}
+}
+
+func TestRun_maxPods(t *testing.T) {
+	tests := map[string]struct {
+		setup          func(o *cmd.IPsOptions)
+		expected       string
+		expectedErrOut string
+	}{
+		"truncated": {
+			setup: func(o *cmd.IPsOptions) {
+				o.SetSortBy("ip")
+				o.SetMaxPods(2)
+			},
+			expected: "NAME    IP           STATUS    AGE\n" +
+				"web-1   10.244.1.5   Running   <unknown>\n" +
+				"batch   10.244.1.9   Failed    <unknown>\n",
+			expectedErrOut: "... (showing 2 of 4)\n",
+		},
+		"ips only": {
+			setup: func(o *cmd.IPsOptions) {
+				o.SetShowIPsOnly(true)
+				o.SetMaxPods(1)
+			},
+			expected:       "10.244.1.9\n",
+			expectedErrOut: "... (showing 1 of 4)\n",
+		},
+		"within limit": {
+			setup: func(o *cmd.IPsOptions) {
+				o.SetLabelSelector("app=batch")
+				o.SetMaxPods(1)
+			},
+			expected: "NAME    IP           STATUS   AGE\n" +
+				"batch   10.244.1.9   Failed   <unknown>\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			out, errOut := runWithFakeClientset(t, tc.setup)
+			assert.Equal(t, tc.expected, out)
+			assert.Equal(t, tc.expectedErrOut, errOut)
+		})
+	}
+}
+
+func TestRun_contexts(t *testing.T) {
+	staging := fake.NewClientset(
+		newRunTestPod("default", "web-1", corev1.PodRunning, map[string]string{"app": "web"}, "10.244.1.5"),
+	)
+
+	tests := map[string]struct {
+		contexts       []string
+		format         string
+		expected       string
+		expectedErrOut string
+	}{
+		"single context": {
+			contexts: []string{"staging"},
+			expected: "NAME    IP           STATUS    AGE\n" +
+				"web-1   10.244.1.5   Running   <unknown>\n",
+		},
+		"json-ips with several contexts": {
+			contexts: []string{"staging", "prod"},
+			format:   "json-ips",
+			expected: `[
+  {
+    "namespace": "default",
+    "pod": "web-1",
+    "ip": "10.244.1.5",
+    "status": "Running",
+    "cluster": "prod"
+  },
+  {
+    "namespace": "default",
+    "pod": "web-1",
+    "ip": "fd00::5",
+    "status": "Running",
+    "cluster": "prod"
+  },
+  {
+    "namespace": "default",
+    "pod": "web-2",
+    "ip": "10.244.1.30",
+    "status": "Running",
+    "cluster": "prod"
+  },
+  {
+    "namespace": "default",
+    "pod": "web-1",
+    "ip": "10.244.1.5",
+    "status": "Running",
+    "cluster": "staging"
+  }
+]
+`,
+		},
+		"name with several contexts": {
+			contexts: []string{"staging", "prod"},
+			format:   "name",
+			expected: "web-1\nweb-1\nweb-2\nweb-1\n",
+		},
+		"several contexts": {
+			contexts: []string{"staging", "prod"},
+			expected: "CLUSTER   NAME    IP            STATUS    AGE\n" +
+				"prod      web-1   10.244.1.5    Running   <unknown>\n" +
+				"prod      web-1   fd00::5       Running   <unknown>\n" +
+				"prod      web-2   10.244.1.30   Running   <unknown>\n" +
+				"staging   web-1   10.244.1.5    Running   <unknown>\n",
+		},
+		"unknown context": {
+			contexts: []string{"prod", "dev"},
+			expected: "CLUSTER   NAME    IP            STATUS    AGE\n" +
+				"prod      web-1   10.244.1.5    Running   <unknown>\n" +
+				"prod      web-1   fd00::5       Running   <unknown>\n" +
+				"prod      web-2   10.244.1.30   Running   <unknown>\n",
+			expectedErrOut: "error: context \"dev\": context not found in kubeconfig: \"dev\"\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			out, errOut := runWithFakeClientset(t, func(o *cmd.IPsOptions) {
+				o.SetLabelSelector("app=web")
+				o.SetContexts(tc.contexts)
+				if tc.format != "" {
+					o.SetOutputFormat(tc.format)
+				}
+				o.SetContextClientsets(map[string]kubernetes.Interface{
+					"prod":    newRunTestClientset(),
+					"staging": staging,
+				})
+			})
+			assert.Equal(t, tc.expected, out)
+			assert.Equal(t, tc.expectedErrOut, errOut)
+		})
+	}
+}
+
+func TestRun_contextsAllFailed(t *testing.T) {
+	streams, _, out, _ := genericiooptions.NewTestIOStreams()
+	options := cmd.NewIPsOptions(streams)
+	options.SetNamespace("default")
+	options.SetOutputFormat("table")
+	options.SetContexts([]string{"dev", "qa"})
+	options.SetContextClientsets(map[string]kubernetes.Interface{})
+	require.NoError(t, options.Validate())
+
+	err := options.Run(context.Background())
+	require.ErrorIs(t, err, cmd.ErrContextNotFound)
+	assert.Contains(t, err.Error(), `context "dev"`)
+	assert.Contains(t, err.Error(), `context "qa"`)
+	assert.Empty(t, out.String())
+}
+
+func TestRun_groupBy(t *testing.T) {
+	onNode := func(pod *corev1.Pod, node string) *corev1.Pod {
+		pod.Spec.NodeName = node
+		return pod
+	}
+	clientset := fake.NewClientset(
+		onNode(newRunTestPod("default", "web-1", corev1.PodRunning, nil, "10.244.1.5"), "worker-2"),
+		onNode(newRunTestPod("default", "web-2", corev1.PodRunning, nil, "10.244.2.7"), "worker-1"),
+		onNode(newRunTestPod("kube-system", "coredns", corev1.PodRunning, nil, "10.244.1.2"), "worker-2"),
+		newRunTestPod("default", "starting", corev1.PodPending, nil),
+	)
+
+	tests := map[string]struct {
+		groupBy  string
+		sortBy   string
+		expected string
+	}{
+		"node": {
+			groupBy: "node",
+			expected: "Node: worker-1\n" +
+				"NAMESPACE   NAME    IP           STATUS    AGE\n" +
+				"default     web-2   10.244.2.7   Running   <unknown>\n" +
+				"\n" +
+				"Node: worker-2\n" +
+				"NAMESPACE     NAME      IP           STATUS    AGE\n" +
+				"default       web-1     10.244.1.5   Running   <unknown>\n" +
+				"kube-system   coredns   10.244.1.2   Running   <unknown>\n" +
+				"\n" +
+				"Node: <none>\n" +
+				"NAMESPACE   NAME       IP       STATUS    AGE\n" +
+				"default     starting   <none>   Pending   <unknown>\n",
+		},
+		"node sorted by ip": {
+			groupBy: "node",
+			sortBy:  "ip",
+			expected: "Node: worker-1\n" +
+				"NAMESPACE   NAME    IP           STATUS    AGE\n" +
+				"default     web-2   10.244.2.7   Running   <unknown>\n" +
+				"\n" +
+				"Node: worker-2\n" +
+				"NAMESPACE     NAME      IP           STATUS    AGE\n" +
+				"kube-system   coredns   10.244.1.2   Running   <unknown>\n" +
+				"default       web-1     10.244.1.5   Running   <unknown>\n" +
+				"\n" +
+				"Node: <none>\n" +
+				"NAMESPACE   NAME       IP       STATUS    AGE\n" +
+				"default     starting   <none>   Pending   <unknown>\n",
+		},
+		"namespace": {
+			groupBy: "namespace",
+			expected: "Namespace: default\n" +
+				"NAME       IP           STATUS    AGE\n" +
+				"starting   <none>       Pending   <unknown>\n" +
+				"web-1      10.244.1.5   Running   <unknown>\n" +
+				"web-2      10.244.2.7   Running   <unknown>\n" +
+				"\n" +
+				"Namespace: kube-system\n" +
+				"NAME      IP           STATUS    AGE\n" +
+				"coredns   10.244.1.2   Running   <unknown>\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, _ := genericiooptions.NewTestIOStreams()
+			options := cmd.NewIPsOptions(streams)
+			options.SetClientset(clientset)
+			options.SetAllNamespaces(true)
+			options.SetShowPending(true)
+			options.SetGroupBy(tc.groupBy)
+			options.SetSortBy(tc.sortBy)
+			require.NoError(t, options.Validate())
+
+			require.NoError(t, options.Run(context.Background()))
+			assert.Equal(t, tc.expected, out.String())
+		})
+	}
+}
+
+func TestRun_watchInterrupted(t *testing.T) {
+	streams, _, out, _ := genericiooptions.NewTestIOStreams()
+	options := cmd.NewIPsOptions(streams)
+	options.SetClientset(newRunTestClientset())
+	options.SetNamespace("default")
+	options.SetWatch(true)
+	require.NoError(t, options.Validate())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := options.Run(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.ErrorContains(t, err, "watch interrupted")
+	// the initial listing is printed before the watch notices the cancellation
+	assert.Contains(t, out.String(), "web-1")
+}
+
+func TestRun_watchDeleted(t *testing.T) {
+	released := newRunTestPod("default", "web-2", corev1.PodRunning, nil)
+
+	tests := map[string]struct {
+		outputFormat string
+		expected     string
+	}{
+		"table": {
+			expected: "NAME    IP            STATUS    AGE\n" +
+				"batch   10.244.1.9    Failed    <unknown>\n" +
+				"web-1   10.244.1.5    Running   <unknown>\n" +
+				"web-1   fd00::5       Running   <unknown>\n" +
+				"web-2   10.244.1.30   Running   <unknown>\n" +
+				"web-1   10.244.1.5    Running   <unknown>\n" +
+				"web-1   fd00::5       Running   <unknown>\n" +
+				"web-1   10.244.1.5    Deleted   <unknown>\n" +
+				"web-1   fd00::5       Deleted   <unknown>\n" +
+				"web-2   <none>        Deleted   <unknown>\n",
+		},
+		"name": {
+			outputFormat: "name",
+			expected: "batch\nweb-1\nweb-1\nweb-2\n" +
+				"web-1\nweb-1\n" +
+				"web-1 deleted\nweb-1 deleted\nweb-2 deleted\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			clientset := newRunTestClientset()
+			web1, err := clientset.CoreV1().Pods("default").Get(context.Background(), "web-1", metav1.GetOptions{})
+			require.NoError(t, err)
+
+			// the events are buffered and the closed channel ends the watch once they are read
+			watcher := watch.NewFakeWithChanSize(3, false)
+			watcher.Modify(web1)
+			watcher.Delete(web1)
+			watcher.Delete(released)
+			watcher.Stop()
+			clientset.PrependWatchReactor("pods", k8stesting.DefaultWatchReactor(watcher, nil))
+
+			streams, _, out, _ := genericiooptions.NewTestIOStreams()
+			options := cmd.NewIPsOptions(streams)
+			options.SetClientset(clientset)
+			options.SetNamespace("default")
+			options.SetOutputFormat(tc.outputFormat)
+			options.SetWatch(true)
+			require.NoError(t, options.Validate())
+
+			require.NoError(t, options.Run(context.Background()))
+			assert.Equal(t, tc.expected, out.String())
+		})
+	}
+}
+
+func TestRun_outputFile(t *testing.T) {
+	t.Run("pods listed", func(t *testing.T) {
+		outputFile := filepath.Join(t.TempDir(), "pod-ips.txt")
+		out, errOut := runWithFakeClientset(t, func(o *cmd.IPsOptions) { o.SetOutputFile(outputFile) })
+		assert.Empty(t, out)
+		assert.Empty(t, errOut)
+
+		data, err := os.ReadFile(outputFile)
+		require.NoError(t, err)
+		assert.Equal(t, "NAME    IP            STATUS    AGE\n"+
+			"batch   10.244.1.9    Failed    <unknown>\n"+
+			"web-1   10.244.1.5    Running   <unknown>\n"+
+			"web-1   fd00::5       Running   <unknown>\n"+
+			"web-2   10.244.1.30   Running   <unknown>\n", string(data))
+	})
+
+	t.Run("no pods found", func(t *testing.T) {
+		outputFile := filepath.Join(t.TempDir(), "pod-ips.txt")
+		require.NoError(t, os.WriteFile(outputFile, []byte("stale\n"), 0o600))
+		out, errOut := runWithFakeClientset(t, func(o *cmd.IPsOptions) {
+			o.SetOutputFile(outputFile)
+			o.SetNamespace("empty")
+		})
+		assert.Empty(t, out)
+		assert.Equal(t, "No pods found in empty\n", errOut)
+
+		data, err := os.ReadFile(outputFile)
+		require.NoError(t, err)
+		assert.Empty(t, data)
+	})
+
+	t.Run("listing failed", func(t *testing.T) {
+		outputFile := filepath.Join(t.TempDir(), "pod-ips.txt")
+		require.NoError(t, os.WriteFile(outputFile, []byte("previous\n"), 0o600))
+
+		streams := genericiooptions.NewTestIOStreamsDiscard()
+		options := cmd.NewIPsOptions(streams)
+		clientset := newRunTestClientset()
+		clientset.PrependReactor("list", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, apierrors.NewInternalError(errors.New("etcd unavailable"))
+		})
+		options.SetClientset(clientset)
+		options.SetNamespace("default")
+		options.SetOutputFile(outputFile)
+		require.NoError(t, options.Validate())
+
+		require.Error(t, options.Run(context.Background()))
+
+		data, err := os.ReadFile(outputFile)
+		require.NoError(t, err)
+		assert.Equal(t, "previous\n", string(data))
+	})
+}
+
+func TestRun_readinessGateStatusFilters(t *testing.T) {
+	gated := newGatedPod(corev1.ConditionFalse, corev1.ConditionFalse)
+	gated.Name = "web-gated"
+	gated.Namespace = "default"
+	gated.Status.PodIP = "10.244.1.8"
+	gated.Status.PodIPs = []corev1.PodIP{{IP: "10.244.1.8"}}
+
+	tests := map[string]func(o *cmd.IPsOptions){
+		"running only":              func(o *cmd.IPsOptions) { o.SetRunningOnly(true) },
+		"status Running":            func(o *cmd.IPsOptions) { o.SetStatuses([]string{"Running"}) },
+		"status Running (NotReady)": func(o *cmd.IPsOptions) { o.SetStatuses([]string{"running (notready)"}) },
+	}
+
+	for name, setup := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, _ := genericiooptions.NewTestIOStreams()
+			options := cmd.NewIPsOptions(streams)
+			options.SetClientset(fake.NewClientset(
+				gated,
+				newRunTestPod("default", "starting", corev1.PodPending, nil, "10.244.1.9"),
+			))
+			options.SetNamespace("default")
+			options.SetOutputFormat("table")
+			setup(options)
+			require.NoError(t, options.Validate())
+
+			require.NoError(t, options.Run(context.Background()))
+			assert.Equal(t, "NAME        IP           STATUS               AGE\n"+
+				"web-gated   10.244.1.8   Running (NotReady)   <unknown>\n", out.String())
+		})
+	}
+}
+
+func TestRun_noPodsFound(t *testing.T) {
+	tests := map[string]struct {
+		setup    func(o *cmd.IPsOptions)
+		expected string
+	}{
+		"empty namespace": {
+			setup:    func(o *cmd.IPsOptions) { o.SetNamespace("empty") },
+			expected: "No pods found in empty\n",
+		},
+		"pods without IPs": {
+			setup:    func(o *cmd.IPsOptions) { o.SetStatuses([]string{"Pending"}) },
+			expected: "No pods found in default with status \"Pending\"\n",
+		},
+		"pod name regex matching nothing": {
+			setup:    func(o *cmd.IPsOptions) { o.SetPodNameRegex("^api-") },
+			expected: "No pods found in default with name matching \"^api-\"\n",
+		},
+		"selector matching nothing": {
+			setup: func(o *cmd.IPsOptions) {
+				o.SetAllNamespaces(true)
+				o.SetLabelSelector("app=none")
+			},
+			expected: "No pods found in all namespaces matching selector \"app=none\"\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			out, errOut := runWithFakeClientset(t, tc.setup)
+			assert.Empty(t, out)
+			assert.Equal(t, tc.expected, errOut)
+		})
+	}
+}
+
+func TestRun_verbose(t *testing.T) {
+	tests := map[string]struct {
+		selector       string
+		expectedOut    string
+		expectedErrOut string
+	}{
+		"pods found": {
+			selector:    "app=web",
+			expectedOut: "web-1\nweb-1\nweb-2\n",
+			expectedErrOut: "Namespace: default\n" +
+				"Label selector: app=web\n" +
+				"Field selector: <none>\n" +
+				"Output format: name\n" +
+				"Found 3 pods in default, 3 IPs matching\n",
+		},
+		"selector matching nothing": {
+			selector: "app=missing",
+			expectedErrOut: "Namespace: default\n" +
+				"Label selector: app=missing\n" +
+				"Field selector: <none>\n" +
+				"Output format: name\n" +
+				"Found 0 pods in default, 0 IPs matching\n" +
+				"No pods found in default matching selector \"app=missing\"\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			out, errOut := runWithFakeClientset(t, func(o *cmd.IPsOptions) {
+				o.SetLabelSelector(tc.selector)
+				o.SetOutputFormat("name")
+				o.SetVerbose(true)
+			})
+			assert.Equal(t, tc.expectedOut, out)
+			assert.Equal(t, tc.expectedErrOut, errOut)
+		})
+	}
+}
+
+func TestRun_diff(t *testing.T) {
+	dir := t.TempDir()
+	snapshot := filepath.Join(dir, "before.json")
+	require.NoError(t, os.WriteFile(snapshot, []byte(`[
+  {"namespace": "default", "pod": "web-0", "ip": "10.244.1.2", "status": "Running"},
+  {"namespace": "default", "pod": "web-1", "ip": "10.244.1.5", "status": "Running"},
+  {"namespace": "default", "pod": "web-1", "ip": "fd00:0000:0000:0000:0000:0000:0000:0005", "status": "Running"},
+  {"namespace": "default", "pod": "web-2", "ip": "10.244.1.20", "status": "Running"}
+]`), 0o600))
+	unchanged := filepath.Join(dir, "unchanged.jsonl")
+	require.NoError(t, os.WriteFile(unchanged, []byte(
+		`{"namespace":"default","pod":"web-1","ip":"10.244.1.5","status":"Running"}`+"\n"+
+			`{"namespace":"default","pod":"web-1","ip":"fd00::5","status":"Running"}`+"\n"+
+			`{"namespace":"default","pod":"web-2","ip":"10.244.1.30","status":"Running"}`+"\n"), 0o600))
+
+	tests := map[string]struct {
+		selector       string
+		snapshot       string
+		expectedOut    string
+		expectedErrOut string
+	}{
+		"changes": {
+			selector: "app in (web, batch)",
+			snapshot: snapshot,
+			expectedOut: "  NAMESPACE   NAME    IP            PREVIOUS-IP\n" +
+				"+ default     batch   10.244.1.9    <none>\n" +
+				"- default     web-0   <none>        10.244.1.2\n" +
+				"~ default     web-2   10.244.1.30   10.244.1.20\n",
+		},
+		"no changes": {
+			selector:       "app=web",
+			snapshot:       unchanged,
+			expectedErrOut: "No pod IP changes since " + unchanged + "\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			out, errOut := runWithFakeClientset(t, func(o *cmd.IPsOptions) {
+				o.SetLabelSelector(tc.selector)
+				o.SetDiff(tc.snapshot)
+			})
+			assert.Equal(t, tc.expectedOut, out)
+			assert.Equal(t, tc.expectedErrOut, errOut)
+		})
+	}
+
+	t.Run("missing snapshot", func(t *testing.T) {
+		options := cmd.NewIPsOptions(genericiooptions.NewTestIOStreamsDiscard())
+		options.SetClientset(newRunTestClientset())
+		options.SetNamespace("default")
+		options.SetDiff(filepath.Join(dir, "missing.json"))
+		require.NoError(t, options.Validate())
+
+		assert.ErrorContains(t, options.Run(context.Background()), "failed to open snapshot")
+	})
+}
+
+func TestRun_forbidden(t *testing.T) {
+	tests := map[string]struct {
+		setup          func(o *cmd.IPsOptions)
+		expectedErr    string
+		expectedErrOut string
+	}{
+		"list in namespace": {
+			setup: func(o *cmd.IPsOptions) { o.SetNamespace("default") },
+			expectedErr: `cannot list pods in namespace "default": forbidden by RBAC, ask a cluster administrator to ` +
+				`bind you to a Role allowing it, e.g. created with: ` +
+				`kubectl create role pods-reader --verb=list --resource=pods -n default`,
+		},
+		"list in all namespaces": {
+			setup: func(o *cmd.IPsOptions) { o.SetAllNamespaces(true) },
+			expectedErr: `cannot list pods in all namespaces: forbidden by RBAC, ask a cluster administrator to ` +
+				`bind you to a ClusterRole allowing it, e.g. created with: ` +
+				`kubectl create clusterrole pods-reader --verb=list --resource=pods`,
+		},
+		"get by name": {
+			setup: func(o *cmd.IPsOptions) {
+				o.SetNamespace("default")
+				o.SetPodNames([]string{"web-1"})
+			},
+			expectedErr: `cannot get pods in namespace "default": forbidden by RBAC, ask a cluster administrator to ` +
+				`bind you to a Role allowing it, e.g. created with: ` +
+				`kubectl create role pods-reader --verb=get --resource=pods -n default`,
+		},
+		"verbose": {
+			setup: func(o *cmd.IPsOptions) {
+				o.SetNamespace("default")
+				o.SetOutputFormat("name")
+				o.SetVerbose(true)
+			},
+			expectedErr: `cannot list pods in namespace "default": forbidden by RBAC, ask a cluster administrator to ` +
+				`bind you to a Role allowing it, e.g. created with: ` +
+				`kubectl create role pods-reader --verb=list --resource=pods -n default`,
+			expectedErrOut: "Namespace: default\n" +
+				"Label selector: <none>\n" +
+				"Field selector: <none>\n" +
+				"Output format: name\n" +
+				"Error: failed to list pods: pods is forbidden: token lacks permissions\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			clientset := fake.NewClientset()
+			clientset.PrependReactor("*", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "",
+					errors.New("token lacks permissions"))
+			})
+
+			streams, _, _, errOut := genericiooptions.NewTestIOStreams()
+			options := cmd.NewIPsOptions(streams)
+			options.SetClientset(clientset)
+			tc.setup(options)
+			require.NoError(t, options.Validate())
+
+			err := options.Run(context.Background())
+			require.EqualError(t, err, tc.expectedErr)
+			require.ErrorIs(t, err, cmd.ErrForbidden)
+			var statusErr *apierrors.StatusError
+			assert.ErrorAs(t, err, &statusErr)
+			assert.Equal(t, tc.expectedErrOut, errOut.String())
+		})
+	}
+}
+
+func TestRun_malformedPodIPs(t *testing.T) {
+	clientset := fake.NewClientset(
+		newRunTestPod("default", "broken", corev1.PodRunning, nil, "10.244.1.300"),
+		newRunTestPod("default", "web", corev1.PodRunning, nil, "10.244.1.5"),
+	)
+
+	tests := map[string]struct {
+		skip           bool
+		expectedOut    string
+		expectedErrOut string
+	}{
+		"kept": {
+			expectedOut: "broken\nweb\n",
+			expectedErrOut: "Warning: pod default/broken has a malformed IP \"10.244.1.300\"\n" +
+				"Found 2 pods in default, 2 IPs matching\n",
+		},
+		"skipped": {
+			skip:        true,
+			expectedOut: "web\n",
+			expectedErrOut: "Warning: pod default/broken has a malformed IP \"10.244.1.300\"\n" +
+				"Found 2 pods in default, 1 IP matching\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, errOut := genericiooptions.NewTestIOStreams()
+			options := cmd.NewIPsOptions(streams)
+			options.SetClientset(clientset)
+			options.SetNamespace("default")
+			options.SetOutputFormat("name")
+			options.SetSkipInvalidIPs(tc.skip)
+			options.SetVerbose(true)
+			require.NoError(t, options.Validate())
+
+			require.NoError(t, options.Run(context.Background()))
+			assert.Equal(t, tc.expectedOut, out.String())
+			assert.True(t, strings.HasSuffix(errOut.String(), tc.expectedErrOut), errOut.String())
+		})
+	}
+}
+
+func TestRun_summary(t *testing.T) {
+	out, _ := runWithFakeClientset(t, func(o *cmd.IPsOptions) {
+		o.SetAllNamespaces(true)
+		o.SetSummary(true)
+	})
+
+	expected := "NAMESPACE     NAME         IP             STATUS    AGE\n" +
+		"default       batch        10.244.1.9     Failed    <unknown>\n" +
+		"default       web-1        10.244.1.5     Running   <unknown>\n" +
+		"default       web-1        fd00::5        Running   <unknown>\n" +
+		"default       web-2        10.244.1.30    Running   <unknown>\n" +
+		"kube-system   coredns      10.244.0.2     Running   <unknown>\n" +
+		"kube-system   kube-proxy   192.168.1.11   Running   <unknown>\n" +
+		"\n" +
+		"default: 3 pods, 4 IPs\n" +
+		"kube-system: 2 pods, 2 IPs\n"
+	assert.Equal(t, expected, out)
+}
+
+func TestRun_wait(t *testing.T) {
+	// every listing finds one more of the pods with an IP
+	newClientset := func() (*fake.Clientset, *int) {
+		clientset := fake.NewClientset()
+		lists := 0
+		clientset.PrependReactor("list", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+			lists++
+			pods := &corev1.PodList{}
+			for i, name := range []string{"web-1", "web-2", "web-3"} {
+				pod := newRunTestPod("default", name, corev1.PodPending, nil)
+				if i < lists-1 {
+					pod = newRunTestPod("default", name, corev1.PodRunning, nil, fmt.Sprintf("10.244.1.%d", i+5))
+				}
+				pods.Items = append(pods.Items, *pod)
+			}
+
+			return true, pods, nil
+		})
+
+		return clientset, &lists
+	}
+
+	run := func(clientset kubernetes.Interface, count int, timeout time.Duration) (string, error) {
+		streams, _, out, _ := genericiooptions.NewTestIOStreams()
+		options := cmd.NewIPsOptions(streams)
+		options.SetClientset(clientset)
+		options.SetNamespace("default")
+		options.SetOutputFormat("name")
+		options.SetWait(count, timeout, time.Millisecond)
+		require.NoError(t, options.Validate())
+		err := options.Run(context.Background())
+
+		return out.String(), err
+	}
+
+	t.Run("enough pods", func(t *testing.T) {
+		clientset, lists := newClientset()
+		out, err := run(clientset, 2, time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, "web-1\nweb-2\n", out)
+		assert.Equal(t, 3, *lists)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		clientset, _ := newClientset()
+		out, err := run(clientset, 4, 50*time.Millisecond)
+		require.ErrorIs(t, err, cmd.ErrWaitTimeout)
+		assert.Contains(t, err.Error(), "3 of 4 pods had an IP")
+		assert.Empty(t, out)
+	})
+}
+
+func TestRun_quiet(t *testing.T) {
+	tests := map[string]struct {
+		setup       func(o *cmd.IPsOptions)
+		expectedErr error
+		expectedOut string
+	}{
+		"no pods": {
+			setup:       func(o *cmd.IPsOptions) { o.SetNamespace("empty") },
+			expectedErr: cmd.ErrNoPodsFound,
+		},
+		"no pod with ip": {
+			setup: func(o *cmd.IPsOptions) {
+				o.SetAllNamespaces(true)
+				o.SetLookup("10.0.0.1")
+			},
+			expectedErr: cmd.ErrNoPodsFound,
+		},
+		"no pods with count": {
+			setup: func(o *cmd.IPsOptions) {
+				o.SetNamespace("empty")
+				o.SetCount(true)
+			},
+			expectedErr: cmd.ErrNoPodsFound,
+		},
+		"no pods with show-ips-only": {
+			setup: func(o *cmd.IPsOptions) {
+				o.SetNamespace("empty")
+				o.SetShowIPsOnly(true)
+			},
+			expectedErr: cmd.ErrNoPodsFound,
+		},
+		"no pods with raw-ips": {
+			setup: func(o *cmd.IPsOptions) {
+				o.SetNamespace("empty")
+				o.SetRawIPs(true)
+			},
+			expectedErr: cmd.ErrNoPodsFound,
+		},
+		"no nodes": {
+			setup:       func(o *cmd.IPsOptions) { o.SetNodes(true) },
+			expectedErr: cmd.ErrNoNodesFound,
+		},
+		"pods found": {
+			setup:       func(o *cmd.IPsOptions) { o.SetLabelSelector("app=batch") },
+			expectedOut: "batch\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, errOut := genericiooptions.NewTestIOStreams()
+			options := cmd.NewIPsOptions(streams)
+			options.SetClientset(newRunTestClientset())
+			options.SetNamespace("default")
+			options.SetOutputFormat("name")
+			options.SetQuiet(true)
+			tc.setup(options)
+			require.NoError(t, options.Validate())
+
+			err := options.Run(context.Background())
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedOut, out.String())
+			assert.Empty(t, errOut.String())
+		})
+	}
+}
+
+func TestRun_failOnEmpty(t *testing.T) {
+	missing := func(o *cmd.IPsOptions) { o.SetLabelSelector("app=missing") }
+	noPodsFound := "No pods found in default matching selector \"app=missing\"\n"
+
+	tests := map[string]struct {
+		setup          func(o *cmd.IPsOptions)
+		expectedErr    error
+		expectedOut    string
+		expectedErrOut string
+	}{
+		"no pods": {
+			setup:          missing,
+			expectedErr:    cmd.ErrNoPodsFound,
+			expectedErrOut: noPodsFound,
+		},
+		"no pods with count": {
+			setup: func(o *cmd.IPsOptions) {
+				missing(o)
+				o.SetCount(true)
+			},
+			expectedErr:    cmd.ErrNoPodsFound,
+			expectedErrOut: noPodsFound,
+		},
+		"no pods with show-ips-only": {
+			setup: func(o *cmd.IPsOptions) {
+				missing(o)
+				o.SetShowIPsOnly(true)
+			},
+			expectedErr:    cmd.ErrNoPodsFound,
+			expectedErrOut: noPodsFound,
+		},
+		"no pods with raw-ips": {
+			setup: func(o *cmd.IPsOptions) {
+				missing(o)
+				o.SetRawIPs(true)
+			},
+			expectedErr:    cmd.ErrNoPodsFound,
+			expectedErrOut: noPodsFound,
+		},
+		"no nodes": {
+			setup:          func(o *cmd.IPsOptions) { o.SetNodes(true) },
+			expectedErr:    cmd.ErrNoNodesFound,
+			expectedErrOut: "No nodes found\n",
+		},
+		"no nodes with show-ips-only": {
+			setup: func(o *cmd.IPsOptions) {
+				o.SetNodes(true)
+				o.SetShowIPsOnly(true)
+			},
+			expectedErr:    cmd.ErrNoNodesFound,
+			expectedErrOut: "No nodes found\n",
+		},
+		"pods found": {
+			setup:       func(o *cmd.IPsOptions) { o.SetLabelSelector("app=batch") },
+			expectedOut: "batch\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, errOut := genericiooptions.NewTestIOStreams()
+			options := cmd.NewIPsOptions(streams)
+			options.SetClientset(newRunTestClientset())
+			options.SetNamespace("default")
+			options.SetOutputFormat("name")
+			tc.setup(options)
+			options.SetFailOnEmpty(true)
+			require.NoError(t, options.Validate())
+
+			err := options.Run(context.Background())
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedOut, out.String())
+			assert.Equal(t, tc.expectedErrOut, errOut.String())
+		})
+	}
+}
+
+func TestRun_lookupMiss(t *testing.T) {
+	tests := map[string]func(o *cmd.IPsOptions){
+		"table":         func(*cmd.IPsOptions) {},
+		"show-ips-only": func(o *cmd.IPsOptions) { o.SetShowIPsOnly(true) },
+		"raw-ips":       func(o *cmd.IPsOptions) { o.SetRawIPs(true) },
+		"count":         func(o *cmd.IPsOptions) { o.SetCount(true) },
+	}
+
+	for name, setup := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, _ := genericiooptions.NewTestIOStreams()
+			options := cmd.NewIPsOptions(streams)
+			options.SetClientset(newRunTestClientset())
+			options.SetAllNamespaces(true)
+			options.SetLookup("10.0.0.1")
+			setup(options)
+			require.NoError(t, options.Validate())
+
+			err := options.Run(context.Background())
+			require.ErrorIs(t, err, cmd.ErrNoPodWithIP)
+			assert.Empty(t, out.String())
+		})
+	}
+}
+
+func TestRun_serverPrint(t *testing.T) {
+	tests := map[string]struct {
+		setup    func(o *cmd.IPsOptions)
+		path     string
+		expected string
+	}{
+		"namespace": {
+			setup: func(*cmd.IPsOptions) {},
+			path:  "/api/v1/namespaces/default/pods",
+			expected: "NAME    READY   STATUS\n" +
+				"web-1   1/1     Running\n" +
+				"web-2   0/1     Pending\n",
+		},
+		"all namespaces": {
+			setup: func(o *cmd.IPsOptions) {
+				o.SetNamespace("")
+				o.SetAllNamespaces(true)
+			},
+			path: "/api/v1/pods",
+			expected: "NAMESPACE   NAME    READY   STATUS\n" +
+				"default     web-1   1/1     Running\n" +
+				"default     web-2   0/1     Pending\n",
+		},
+		"wide": {
+			setup: func(o *cmd.IPsOptions) { o.SetOutputFormat("wide") },
+			path:  "/api/v1/namespaces/default/pods",
+			expected: "NAME    READY   STATUS    IP\n" +
+				"web-1   1/1     Running   10.244.1.5\n" +
+				"web-2   0/1     Pending   <none>\n",
+		},
+	}
+
+	// the second page is served for the continue token of the first one
+	pages := map[string]string{
+		"": `{"kind":"Table","apiVersion":"meta.k8s.io/v1","metadata":{"continue":"next"},` +
+			`"columnDefinitions":[{"name":"Name","type":"string"},{"name":"Ready","type":"string"},` +
+			`{"name":"Status","type":"string"},{"name":"IP","type":"string","priority":1}],` +
+			`"rows":[{"cells":["web-1","1/1","Running","10.244.1.5"],"object":{"kind":"PartialObjectMetadata",` +
+			`"apiVersion":"meta.k8s.io/v1","metadata":{"name":"web-1","namespace":"default"}}}]}`,
+		"next": `{"kind":"Table","apiVersion":"meta.k8s.io/v1","metadata":{},"rows":[` +
+			`{"cells":["web-2","0/1","Pending","<none>"],"object":{"kind":"PartialObjectMetadata",` +
+			`"apiVersion":"meta.k8s.io/v1","metadata":{"name":"web-2","namespace":"default"}}}]}`,
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tc.path, r.URL.Path)
+				assert.Contains(t, r.Header.Get("Accept"), "as=Table;v=v1;g=meta.k8s.io")
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(pages[r.URL.Query().Get("continue")]))
+			}))
+			defer server.Close()
+
+			clientset, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL})
+			require.NoError(t, err)
+
+			streams, _, out, _ := genericiooptions.NewTestIOStreams()
+			options := cmd.NewIPsOptions(streams)
+			options.SetClientset(clientset)
+			options.SetNamespace("default")
+			options.SetOutputFormat("table")
+			options.SetServerPrint(true)
+			tc.setup(options)
+			require.NoError(t, options.Validate())
+
+			require.NoError(t, options.Run(context.Background()))
+			assert.Equal(t, tc.expected, out.String())
+		})
+	}
+}
+
+func TestRun_serverPrintUnsupported(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"kind":"PodList","apiVersion":"v1","metadata":{},"items":[]}`))
+	}))
+	defer server.Close()
+
+	clientset, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL})
+	require.NoError(t, err)
+
+	streams := genericiooptions.NewTestIOStreamsDiscard()
+	options := cmd.NewIPsOptions(streams)
+	options.SetClientset(clientset)
+	options.SetNamespace("default")
+	options.SetServerPrint(true)
+
+	err = options.Run(context.Background())
+	assert.ErrorIs(t, err, cmd.ErrServerPrintUnsupported)
+}
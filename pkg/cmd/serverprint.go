@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// serverTableAccept asks the API server for a table, falling back to the plain list on servers without tables.
+const serverTableAccept = "application/json;as=Table;v=v1;g=meta.k8s.io,application/json"
+
+// runServerPrint prints the table of pods built by the API server, with the columns of kubectl get pods, instead
+// of building it from the listed pods.
+func (o *IPsOptions) runServerPrint(ctx context.Context, clientset kubernetes.Interface) error {
+	var table *metav1.Table
+	err := o.withTimeout(ctx, func(ctx context.Context) error {
+		return o.withRetries(ctx, func() error {
+			listOptions, err := o.podListOptionsIn(ctx, clientset, o.namespace)
+			if err != nil {
+				return err
+			}
+			table, err = listPodTable(ctx, clientset, o.namespace, listOptions, o.chunkSize)
+
+			return o.explainForbidden(err, "list", "pods", o.namespace)
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(table.Rows) == 0 {
+		return o.printNoPodsFound()
+	}
+
+	var printer ResourcePrinter = printers.NewTablePrinter(printers.PrintOptions{
+		NoHeaders:     o.noHeaders,
+		Wide:          o.outputFormat == wideFormat,
+		WithNamespace: o.allNamespaces,
+	})
+	if o.maxColumnWidth > 0 {
+		printer = &truncatingPrinter{printer: printer, maxWidth: o.maxColumnWidth}
+	}
+	if err := printer.PrintObj(table, o.Out); err != nil {
+		return fmt.Errorf("failed to print object: %w", err)
+	}
+
+	return nil
+}
+
+// listPodTable lists the pods as a server-side table in chunks of chunkSize, following continue tokens until
+// every row is accumulated.
+func listPodTable(
+	ctx context.Context, clientset kubernetes.Interface, namespace string, listOptions metav1.ListOptions, chunkSize int64,
+) (*metav1.Table, error) {
+	listOptions.Limit = chunkSize
+	table := &metav1.Table{}
+
+	for {
+		data, err := clientset.CoreV1().RESTClient().Get().
+			Namespace(namespace).
+			Resource("pods").
+			VersionedParams(&listOptions, scheme.ParameterCodec).
+			SetHeader("Accept", serverTableAccept).
+			DoRaw(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pods: %w", classifyAPIError(err))
+		}
+
+		page := &metav1.Table{}
+		if err := json.Unmarshal(data, page); err != nil {
+			return nil, fmt.Errorf("failed to decode pod table: %w", err)
+		}
+		if page.Kind != "Table" {
+			return nil, fmt.Errorf("%w: got %s", ErrServerPrintUnsupported, page.Kind)
+		}
+		if err := decodeRowMetadata(page); err != nil {
+			return nil, err
+		}
+
+		if table.ColumnDefinitions == nil {
+			table.ColumnDefinitions = page.ColumnDefinitions
+		}
+		table.Rows = append(table.Rows, page.Rows...)
+
+		if page.Continue == "" {
+			return table, nil
+		}
+		listOptions.Continue = page.Continue
+	}
+}
+
+// decodeRowMetadata decodes the object metadata the API server attaches to every row, which the table printer
+// reads the namespace of the row from.
+func decodeRowMetadata(table *metav1.Table) error {
+	for i := range table.Rows {
+		raw := table.Rows[i].Object.Raw
+		if len(raw) == 0 {
+			continue
+		}
+
+		metadata := &metav1.PartialObjectMetadata{}
+		if err := json.Unmarshal(raw, metadata); err != nil {
+			return fmt.Errorf("failed to decode pod table row: %w", err)
+		}
+		table.Rows[i].Object.Object = metadata
+	}
+
+	return nil
+}
+
+// validateServerPrint rejects the flags that filter the IPs or shape the output on the client, which the table of
+// the API server knows nothing about.
+func (o *IPsOptions) validateServerPrint() error {
+	if !o.serverPrint {
+		return nil
+	}
+
+	switch {
+	case o.outputFormat != tableFormat && o.outputFormat != wideFormat && o.outputFormat != "":
+		return fmt.Errorf("%w: --server-print can only be used with table or wide output", ErrIncompatibleFlags)
+	case len(o.podNames) > 0:
+		return fmt.Errorf("%w: pod names cannot be used with --server-print", ErrIncompatibleFlags)
+	case len(o.contexts) > 0:
+		return fmt.Errorf("%w: --contexts cannot be used with --server-print", ErrIncompatibleFlags)
+	case o.filename != "":
+		return fmt.Errorf("%w: --filename cannot be used with --server-print", ErrIncompatibleFlags)
+	case o.watch:
+		return fmt.Errorf("%w: --watch cannot be used with --server-print", ErrIncompatibleFlags)
+	case o.wait:
+		return fmt.Errorf("%w: --wait cannot be used with --server-print", ErrIncompatibleFlags)
+	case o.nodes:
+		return fmt.Errorf("%w: --nodes cannot be used with --server-print", ErrIncompatibleFlags)
+	case o.count:
+		return fmt.Errorf("%w: --count cannot be used with --server-print", ErrIncompatibleFlags)
+	case o.summary:
+		return fmt.Errorf("%w: --summary cannot be used with --server-print", ErrIncompatibleFlags)
+	case o.diff != "":
+		return fmt.Errorf("%w: --diff cannot be used with --server-print", ErrIncompatibleFlags)
+	case o.showIPsOnly:
+		return fmt.Errorf("%w: --show-ips-only cannot be used with --server-print", ErrIncompatibleFlags)
+	case o.rawIPs:
+		return fmt.Errorf("%w: --raw-ips cannot be used with --server-print", ErrIncompatibleFlags)
+	case o.dualStack:
+		return fmt.Errorf("%w: --wide-with-ipv6 cannot be used with --server-print", ErrIncompatibleFlags)
+	case o.includeServices:
+		return fmt.Errorf("%w: --include-services cannot be used with --server-print", ErrIncompatibleFlags)
+	case o.cidr != "":
+		return fmt.Errorf("%w: --cidr cannot be used with --server-print", ErrIncompatibleFlags)
+	case o.lookup != "":
+		return fmt.Errorf("%w: --lookup cannot be used with --server-print", ErrIncompatibleFlags)
+	case o.podNameRegex != "":
+		return fmt.Errorf("%w: --pod-name-regex cannot be used with --server-print", ErrIncompatibleFlags)
+	case o.excludePodRegex != "":
+		return fmt.Errorf("%w: --exclude-pod-regex cannot be used with --server-print", ErrIncompatibleFlags)
+	case len(o.excludeNamespaces) > 0:
+		return fmt.Errorf("%w: --exclude-namespace cannot be used with --server-print", ErrIncompatibleFlags)
+	case len(o.statuses) > 0:
+		return fmt.Errorf("%w: --status cannot be used with --server-print", ErrIncompatibleFlags)
+	case o.runningOnly:
+		return fmt.Errorf("%w: --running-only cannot be used with --server-print", ErrIncompatibleFlags)
+	case o.excludeHostNetwork:
+		return fmt.Errorf("%w: --exclude-host-network cannot be used with --server-print", ErrIncompatibleFlags)
+	case o.hostNetworkOnly:
+		return fmt.Errorf("%w: --host-network-only cannot be used with --server-print", ErrIncompatibleFlags)
+	case o.ipFamily != ipFamilyAll && o.ipFamily != "":
+		return fmt.Errorf("%w: --ip-family cannot be used with --server-print", ErrIncompatibleFlags)
+	case o.since > 0:
+		return fmt.Errorf("%w: --since cannot be used with --server-print", ErrIncompatibleFlags)
+	case o.olderThan > 0:
+		return fmt.Errorf("%w: --older-than cannot be used with --server-print", ErrIncompatibleFlags)
+	case o.maxPods > 0:
+		return fmt.Errorf("%w: --max-pods cannot be used with --server-print", ErrIncompatibleFlags)
+	case o.sortBy != sortByDefault:
+		return fmt.Errorf("%w: --sort-by cannot be used with --server-print", ErrIncompatibleFlags)
+	case o.reverse:
+		return fmt.Errorf("%w: --reverse cannot be used with --server-print", ErrIncompatibleFlags)
+	case o.showLabels:
+		return fmt.Errorf("%w: --show-labels cannot be used with --server-print", ErrIncompatibleFlags)
+	case o.showPorts:
+		return fmt.Errorf("%w: --show-ports cannot be used with --server-print", ErrIncompatibleFlags)
+	case len(o.labelColumns) > 0:
+		return fmt.Errorf("%w: --label-columns cannot be used with --server-print", ErrIncompatibleFlags)
+	case o.containers:
+		return fmt.Errorf("%w: --containers cannot be used with --server-print", ErrIncompatibleFlags)
+	case o.showPending:
+		return fmt.Errorf("%w: --show-pending cannot be used with --server-print", ErrIncompatibleFlags)
+	case o.ipSource != ipSourceStatus && o.ipSource != "":
+		return fmt.Errorf("%w: --ip-source cannot be used with --server-print", ErrIncompatibleFlags)
+	case o.allInterfaces:
+		return fmt.Errorf("%w: --all-interfaces cannot be used with --server-print", ErrIncompatibleFlags)
+	case len(o.annotationColumns) > 0:
+		return fmt.Errorf("%w: --annotation-columns cannot be used with --server-print", ErrIncompatibleFlags)
+	case len(o.nodeLabelColumns) > 0:
+		return fmt.Errorf("%w: --show-node-labels cannot be used with --server-print", ErrIncompatibleFlags)
+	case o.showImages:
+		return fmt.Errorf("%w: --show-images cannot be used with --server-print", ErrIncompatibleFlags)
+	case o.showDNS:
+		return fmt.Errorf("%w: --show-dns cannot be used with --server-print", ErrIncompatibleFlags)
+	case o.showAgeTimestamp:
+		return fmt.Errorf("%w: --show-age-timestamp cannot be used with --server-print", ErrIncompatibleFlags)
+	case o.alignedColumns:
+		return fmt.Errorf("%w: --aligned-columns cannot be used with --server-print", ErrIncompatibleFlags)
+	case o.color != colorAuto && o.color != "":
+		return fmt.Errorf("%w: --color cannot be used with --server-print", ErrIncompatibleFlags)
+	case o.headerStyle != headerStyleUpper && o.headerStyle != "":
+		return fmt.Errorf("%w: --header-style cannot be used with --server-print", ErrIncompatibleFlags)
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// podListOptionsIn returns the options for listing pods in the namespace, selecting the pods backing the service
+// passed to --service, or the pods labeled like the one passed to --selector-from-pod, in place of the label
+// selector.
+func (o *IPsOptions) podListOptionsIn(
+	ctx context.Context, clientset kubernetes.Interface, namespace string,
+) (metav1.ListOptions, error) {
+	listOptions := o.podListOptions()
+
+	var selector string
+	var err error
+	switch {
+	case o.service != "":
+		selector, err = serviceSelector(ctx, clientset, namespace, o.service)
+	case o.selectorFromPod != "":
+		selector, err = podSelector(ctx, clientset, namespace, o.selectorFromPod)
+	default:
+		return listOptions, nil
+	}
+	if err != nil {
+		return metav1.ListOptions{}, err
+	}
+	listOptions.LabelSelector = selector
+
+	return listOptions, nil
+}
+
+// podInstanceLabels are set by controllers to tell apart the pods of a workload or its revisions, so they are
+// left out of the selector built from a pod's labels.
+var podInstanceLabels = []string{
+	"pod-template-hash",
+	"controller-revision-hash",
+	"pod-template-generation",
+	"statefulset.kubernetes.io/pod-name",
+	"apps.kubernetes.io/pod-index",
+	"batch.kubernetes.io/job-completion-index",
+}
+
+// podSelector returns the label selector matching the pods labeled like the named one, leaving out the labels
+// that differ between replicas and revisions. Pods without other labels are rejected as they would select every pod.
+func podSelector(ctx context.Context, clientset kubernetes.Interface, namespace, name string) (string, error) {
+	pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get pod %q: %w", name, classifyAPIError(err))
+	}
+
+	set := labels.Set{}
+	for key, value := range pod.Labels {
+		if !slices.Contains(podInstanceLabels, key) {
+			set[key] = value
+		}
+	}
+	if len(set) == 0 {
+		return "", fmt.Errorf("%w: pod %q in namespace %q", ErrPodWithoutLabels, name, namespace)
+	}
+
+	return labels.SelectorFromSet(set).String(), nil
+}
+
+// serviceSelector returns the label selector matching the pods backing the service. Services without a selector,
+// such as those with manually managed endpoints, are rejected as they select no pods.
+func serviceSelector(ctx context.Context, clientset kubernetes.Interface, namespace, name string) (string, error) {
+	service, err := clientset.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get service %q: %w", name, classifyAPIError(err))
+	}
+
+	if len(service.Spec.Selector) == 0 {
+		return "", fmt.Errorf("%w: service %q in namespace %q, use --include-endpoints to list its endpoint addresses",
+			ErrServiceWithoutSelector, name, namespace)
+	}
+
+	return labels.SelectorFromSet(service.Spec.Selector).String(), nil
+}
+
+// getEndpointSlices lists the endpoint slices of the service passed to --service, found by the label the
+// endpoint slice controller and well-behaved tools set on them.
+func (o *IPsOptions) getEndpointSlices(
+	ctx context.Context, clientset kubernetes.Interface, namespace string,
+) (*discoveryv1.EndpointSliceList, error) {
+	var endpointSlices *discoveryv1.EndpointSliceList
+	err := o.withTimeout(ctx, func(ctx context.Context) error {
+		var err error
+		endpointSlices, err = clientset.DiscoveryV1().EndpointSlices(namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: labels.SelectorFromSet(labels.Set{discoveryv1.LabelServiceName: o.service}).String(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list endpoint slices: %w", classifyAPIError(err))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return endpointSlices, nil
+}
@@ -1,76 +1,834 @@
 package cmd
 
 import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net"
+	"net/netip"
+	"regexp"
+	"slices"
 	"sort"
+	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// podIPWithPod is an IP address with the pod owning it, or the service or endpoint slice when those are included.
+// The cluster is the kubeconfig context the object was listed from when several are queried.
 type podIPWithPod struct {
-	pod *corev1.Pod
-	ip  string
+	pod     *corev1.Pod
+	service *corev1.Service
+	ip      string
+	cluster string
+	// iface is the pod interface reporting the IP, only known when it is read from the network-status annotation
+	iface string
+
+	// endpoint is the address entry of the endpoint slice reporting the IP
+	endpointSlice *discoveryv1.EndpointSlice
+	endpoint      *discoveryv1.Endpoint
+
+	// ipv6 is only set by pairIPFamilies, which leaves the IPv4 address in ip
+	ipv6 string
+	// secondaryIPs are only set by groupSecondaryIPs, which leaves the primary address in ip
+	secondaryIPs []string
+
+	// node is the node running the pod or endpoint, only looked up for --show-node-labels
+	node *corev1.Node
+}
+
+func (p podIPWithPod) meta() *metav1.ObjectMeta {
+	switch {
+	case p.service != nil:
+		return &p.service.ObjectMeta
+	case p.endpointSlice != nil:
+		return &p.endpointSlice.ObjectMeta
+	default:
+		return &p.pod.ObjectMeta
+	}
+}
+
+// nodeName returns the name of the node running the pod or endpoint, empty for services and unscheduled pods.
+func (p podIPWithPod) nodeName() string {
+	switch {
+	case p.service != nil:
+		return ""
+	case p.endpoint != nil:
+		if p.endpoint.NodeName == nil {
+			return ""
+		}
+
+		return *p.endpoint.NodeName
+	default:
+		return p.pod.Spec.NodeName
+	}
+}
+
+// nodeLabels returns the labels of the node looked up for the entry, nil when there is none.
+func (p podIPWithPod) nodeLabels() map[string]string {
+	if p.node == nil {
+		return nil
+	}
+
+	return p.node.Labels
+}
+
+func (p podIPWithPod) object() runtime.Object {
+	switch {
+	case p.service != nil:
+		return p.service
+	case p.endpointSlice != nil:
+		return p.endpointSlice
+	default:
+		return p.pod
+	}
+}
+
+// primaryIP returns the primary address of the pod or service in its canonical form, empty for endpoints.
+func (p podIPWithPod) primaryIP() string {
+	switch {
+	case p.service != nil:
+		return canonicalIP(p.service.Spec.ClusterIP)
+	case p.endpointSlice != nil:
+		return ""
+	default:
+		return canonicalIP(p.pod.Status.PodIP)
+	}
+}
+
+func (p podIPWithPod) restarts(includeInit bool) int32 {
+	if p.pod == nil {
+		return 0
+	}
+
+	return podRestarts(p.pod, includeInit)
+}
+
+func (p podIPWithPod) phase() string {
+	if p.pod == nil {
+		return ""
+	}
+
+	return string(p.pod.Status.Phase)
+}
+
+// ipSources holds the objects whose IP addresses are listed.
+type ipSources struct {
+	pods           *corev1.PodList
+	services       *corev1.ServiceList
+	endpointSlices *discoveryv1.EndpointSliceList
 }
 
-func generateTable(pods *corev1.PodList, showNamespace, wide, showLabels bool) *metav1.Table {
-	podIPList := extractPodIPsWithPods(pods)
-	sortPodIPsWithPods(podIPList)
+// ipListOptions selects and orders the pod IP addresses to print.
+type ipListOptions struct {
+	filter  ipFilter
+	sortBy  string
+	reverse bool
+	// noSort keeps the listing order of the API server, still reversed by reverse
+	noSort bool
+	// includeInitRestarts counts init container restarts when sorting by restarts
+	includeInitRestarts bool
+}
+
+// columnOptions controls which optional columns are included in the table.
+type columnOptions struct {
+	showCluster   bool
+	showNamespace bool
+	showType      bool
+	containers    bool
+	wide          bool
+	showLabels    bool
+	showPorts     bool
+	showImages    bool
+	showDNS       bool
+	clusterDomain string
+	// includeInitRestarts adds init container restarts to the pod's RESTARTS column
+	includeInitRestarts bool
+	// labelColumns and annotationColumns are the label and annotation keys shown as a column each
+	labelColumns      []string
+	annotationColumns []string
+	// nodeLabelColumns are the keys of the node labels shown as a column each
+	nodeLabelColumns []string
+	// expandIPv6 writes IPv6 addresses in full instead of their compressed canonical form
+	expandIPv6 bool
+	// dualStack replaces the IP column with IPV4 and IPV6 columns for entries paired by pairIPFamilies
+	dualStack bool
+	// showInterface adds an INTERFACE column after the IP with the pod interface reporting it
+	showInterface bool
+	// secondaryIPs adds a SECONDARY-IPS column after the IP for entries grouped by groupSecondaryIPs
+	secondaryIPs bool
+	// ageTimestamp prints the creation timestamp in the AGE column instead of the relative age, in location or
+	// the local time zone when nil
+	ageTimestamp bool
+	location     *time.Location
+}
+
+// ipFilter decides which pod IP addresses are included in the output.
+type ipFilter struct {
+	cidr    *net.IPNet
+	family  string
+	address net.IP
+	// source reads the pod addresses from the status or the network-status annotation
+	source string
+
+	excludedNamespaces []string
+	statuses           []string
+	podNamePattern     *regexp.Regexp
+	excludePodPattern  *regexp.Regexp
+	excludeHostNetwork bool
+	hostNetworkOnly    bool
+	keepDuplicates     bool
+	includePending     bool
+	// skipInvalid leaves out the pod addresses that do not parse as IP addresses
+	skipInvalid bool
+
+	// createdAfter and createdBefore, when set, keep only the pods created within these bounds
+	createdAfter  time.Time
+	createdBefore time.Time
+}
+
+// includesNetworking reports whether the pod passes the host network filters.
+func (f ipFilter) includesNetworking(pod *corev1.Pod) bool {
+	switch {
+	case f.excludeHostNetwork:
+		return !pod.Spec.HostNetwork
+	case f.hostNetworkOnly:
+		return pod.Spec.HostNetwork
+	default:
+		return true
+	}
+}
+
+// includesAge reports whether the pod was created within the --since and --older-than bounds.
+func (f ipFilter) includesAge(pod *corev1.Pod) bool {
+	return (f.createdAfter.IsZero() || pod.CreationTimestamp.After(f.createdAfter)) &&
+		(f.createdBefore.IsZero() || pod.CreationTimestamp.Time.Before(f.createdBefore))
+}
+
+// includesNamespace reports whether objects in the namespace are listed at all.
+func (f ipFilter) includesNamespace(namespace string) bool {
+	return !slices.Contains(f.excludedNamespaces, namespace)
+}
+
+// includesStatus reports whether the pod's status is one of the requested ones, ignoring case. A running pod held
+// back by a readiness gate matches both Running and its displayed "Running (NotReady)".
+func (f ipFilter) includesStatus(pod *corev1.Pod) bool {
+	if len(f.statuses) == 0 {
+		return true
+	}
+
+	status, displayed := podStatus(pod), FormatPodStatus(pod)
+
+	return slices.ContainsFunc(f.statuses, func(want string) bool {
+		return strings.EqualFold(want, status) || strings.EqualFold(want, displayed)
+	})
+}
+
+// includesName reports whether the pod's name matches --pod-name-regex, accepting every pod when it is not set,
+// and does not match --exclude-pod-regex.
+func (f ipFilter) includesName(pod *corev1.Pod) bool {
+	return (f.podNamePattern == nil || f.podNamePattern.MatchString(pod.Name)) &&
+		(f.excludePodPattern == nil || !f.excludePodPattern.MatchString(pod.Name))
+}
+
+func (f ipFilter) matches(ip string) bool {
+	if f.cidr == nil && f.address == nil && (f.family == "" || f.family == ipFamilyAll) {
+		return true
+	}
+
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+
+	switch f.family {
+	case ipFamilyIPv4:
+		if parsed.To4() == nil {
+			return false
+		}
+	case ipFamilyIPv6:
+		if parsed.To4() != nil {
+			return false
+		}
+	}
 
+	if f.address != nil && !f.address.Equal(parsed) {
+		return false
+	}
+
+	return f.cidr == nil || f.cidr.Contains(parsed)
+}
+
+func generateTable(sources ipSources, listOptions ipListOptions, columns columnOptions) *metav1.Table {
+	podIPs := listPodIPs(sources, listOptions)
+	if columns.dualStack {
+		podIPs = pairIPFamilies(podIPs)
+	}
+	if columns.secondaryIPs {
+		podIPs = groupSecondaryIPs(podIPs)
+	}
+
+	return makeIPTable(podIPs, columns)
+}
+
+// makeIPTable builds a table row for every entry, keeping their order.
+func makeIPTable(podIPList []podIPWithPod, columns columnOptions) *metav1.Table {
 	table := &metav1.Table{
-		ColumnDefinitions: makeTableHeaders(showNamespace, wide, showLabels),
+		ColumnDefinitions: makeTableHeaders(columns),
 	}
+	secondaryColumn := columnIndex(table, "SECONDARY-IPS")
+	interfaceColumn := columnIndex(table, "INTERFACE")
 
 	for _, item := range podIPList {
-		row := metav1.TableRow{
-			Cells: makeTableRow(item.pod, item.ip, showNamespace, wide, showLabels),
-			Object: runtime.RawExtension{
-				Object: item.pod,
-			},
+		for _, cells := range makeItemRows(item, columns) {
+			if columns.showCluster {
+				cells = append([]any{item.cluster}, cells...)
+			}
+			if secondaryColumn >= 0 {
+				cells = slices.Insert(cells, secondaryColumn, any(formatSecondaryIPs(item.secondaryIPs, columns)))
+			}
+			if interfaceColumn >= 0 {
+				cells = slices.Insert(cells, interfaceColumn, any(cmp.Or(item.iface, noneValue)))
+			}
+			if len(columns.nodeLabelColumns) > 0 {
+				// node label columns go before the LABELS column, which stays last
+				at := len(cells)
+				if columns.showLabels {
+					at--
+				}
+				cells = slices.Insert(cells, at, FormatValues(item.nodeLabels(), columns.nodeLabelColumns)...)
+			}
+
+			row := metav1.TableRow{
+				Cells: cells,
+				Object: runtime.RawExtension{
+					Object: item.object(),
+				},
+			}
+			table.Rows = append(table.Rows, row)
 		}
-		table.Rows = append(table.Rows, row)
 	}
 
 	return table
 }
 
-func extractPodIPsWithPods(pods *corev1.PodList) []podIPWithPod {
+// makeItemRows returns the cells of the entry, one row per container of the pod when containers are expanded.
+func makeItemRows(item podIPWithPod, columns columnOptions) [][]any {
+	if item.service != nil {
+		return [][]any{makeServiceTableRow(item.service, item.ip, item.ipv6, columns)}
+	}
+	if item.endpointSlice != nil {
+		return [][]any{makeEndpointTableRow(item.endpointSlice, item.endpoint, item.ip, item.ipv6, columns)}
+	}
+
+	containers := item.pod.Spec.Containers
+	if !columns.containers || len(containers) == 0 {
+		return [][]any{makeTableRow(item.pod, item.ip, item.ipv6, "", columns)}
+	}
+
+	rows := make([][]any, 0, len(containers))
+	for i := range containers {
+		rows = append(rows, makeTableRow(item.pod, item.ip, item.ipv6, containers[i].Name, columns))
+	}
+
+	return rows
+}
+
+// listPodIPs extracts the matching IP addresses from all sources and sorts them in the requested order.
+func listPodIPs(sources ipSources, listOptions ipListOptions) []podIPWithPod {
+	return orderPodIPs(extractIPs(sources, listOptions.filter), listOptions)
+}
+
+// extractIPs collects the matching IP addresses of the pods, services and endpoint slices, in listing order.
+func extractIPs(sources ipSources, filter ipFilter) []podIPWithPod {
+	podIPs := extractPodIPsWithPods(sources.pods, filter)
+	if sources.services != nil {
+		podIPs = append(podIPs, extractServiceIPs(sources.services, filter)...)
+	}
+	if sources.endpointSlices != nil {
+		podIPs = append(podIPs, extractEndpointIPs(sources.endpointSlices, filter)...)
+	}
+
+	return podIPs
+}
+
+// pairIPFamilies collapses the entries of every object into one for the dual-stack layout, holding the IPv4
+// address in ip and the IPv6 address in ipv6. Each entry keeps the position of the object's first address.
+func pairIPFamilies(podIPs []podIPWithPod) []podIPWithPod {
+	type pairKey struct {
+		object   runtime.Object
+		endpoint *discoveryv1.Endpoint
+	}
+
+	paired := make([]podIPWithPod, 0, len(podIPs))
+	indexes := make(map[pairKey]int)
+
+	for _, item := range podIPs {
+		key := pairKey{object: item.object(), endpoint: item.endpoint}
+		index, ok := indexes[key]
+		if !ok {
+			index = len(paired)
+			indexes[key] = index
+			entry := item
+			entry.ip = ""
+			paired = append(paired, entry)
+		}
+
+		slot := &paired[index].ip
+		if parsed := net.ParseIP(item.ip); parsed != nil && parsed.To4() == nil {
+			slot = &paired[index].ipv6
+		}
+		// the primary address comes first, so a second one of the same family is left out
+		if *slot == "" {
+			*slot = item.ip
+		}
+	}
+
+	return paired
+}
+
+// groupSecondaryIPs collapses the entries of every object into one for -o wide-ipv6, holding the primary address
+// in ip and the others in secondaryIPs, in their sorted order. The primary address is the pod's PodIP or the
+// service's ClusterIP wherever the sort put it, falling back to the first address when it was filtered out. Each
+// entry keeps the position of the object's first address.
+func groupSecondaryIPs(podIPs []podIPWithPod) []podIPWithPod {
+	type groupKey struct {
+		object   runtime.Object
+		endpoint *discoveryv1.Endpoint
+	}
+
+	grouped := make([]podIPWithPod, 0, len(podIPs))
+	indexes := make(map[groupKey]int)
+
+	for _, item := range podIPs {
+		key := groupKey{object: item.object(), endpoint: item.endpoint}
+		index, ok := indexes[key]
+		if !ok {
+			indexes[key] = len(grouped)
+			grouped = append(grouped, item)
+
+			continue
+		}
+
+		entry := &grouped[index]
+		if item.ip != "" && item.ip == item.primaryIP() {
+			item.secondaryIPs = slices.Insert(entry.secondaryIPs, 0, entry.ip)
+			*entry = item
+		} else {
+			entry.secondaryIPs = append(entry.secondaryIPs, item.ip)
+		}
+	}
+
+	return grouped
+}
+
+// orderPodIPs sorts the entries by the requested key, reversing them if asked to.
+func orderPodIPs(podIPs []podIPWithPod, listOptions ipListOptions) []podIPWithPod {
+	if !listOptions.noSort {
+		sortPodIPsWithPods(podIPs, listOptions)
+	}
+	if listOptions.reverse {
+		slices.Reverse(podIPs)
+	}
+
+	return podIPs
+}
+
+// ipSummary tallies the listed IP addresses and the objects owning them.
+type ipSummary struct {
+	ips            int
+	pods           int
+	services       int
+	endpointSlices int
+	namespaces     int
+}
+
+func summarizeIPs(podIPs []podIPWithPod) ipSummary {
+	pods := make(map[*corev1.Pod]bool)
+	services := make(map[*corev1.Service]bool)
+	endpointSlices := make(map[*discoveryv1.EndpointSlice]bool)
+	namespaces := make(map[string]bool)
+	ips := 0
+
+	for _, item := range podIPs {
+		// pods listed without an IP address are not counted, and entries paired by pairIPFamilies hold two
+		addresses := 0
+		for _, ip := range []string{item.ip, item.ipv6} {
+			if ip != "" {
+				addresses++
+			}
+		}
+		if addresses == 0 {
+			continue
+		}
+		ips += addresses
+		switch {
+		case item.service != nil:
+			services[item.service] = true
+		case item.endpointSlice != nil:
+			endpointSlices[item.endpointSlice] = true
+		default:
+			pods[item.pod] = true
+		}
+		namespaces[item.meta().Namespace] = true
+	}
+
+	return ipSummary{
+		ips:            ips,
+		pods:           len(pods),
+		services:       len(services),
+		endpointSlices: len(endpointSlices),
+		namespaces:     len(namespaces),
+	}
+}
+
+func (s ipSummary) String() string {
+	owners := []string{pluralize(s.pods, "pod")}
+	if s.services > 0 {
+		owners = append(owners, pluralize(s.services, "service"))
+	}
+	if s.endpointSlices > 0 {
+		owners = append(owners, pluralize(s.endpointSlices, "endpoint slice"))
+	}
+	last := len(owners) - 1
+	owner := owners[last]
+	if last > 0 {
+		owner = strings.Join(owners[:last], ", ") + " and " + owner
+	}
+
+	return fmt.Sprintf("Total: %s across %s in %s",
+		pluralize(s.ips, "IP"), owner, pluralize(s.namespaces, "namespace"))
+}
+
+// namespaceSummaries tallies the entries of every namespace for the --summary footer, ordered by namespace.
+func namespaceSummaries(podIPs []podIPWithPod) []string {
+	byNamespace := make(map[string][]podIPWithPod)
+	for _, item := range podIPs {
+		namespace := item.meta().Namespace
+		byNamespace[namespace] = append(byNamespace[namespace], item)
+	}
+
+	lines := make([]string, 0, len(byNamespace))
+	for _, namespace := range slices.Sorted(maps.Keys(byNamespace)) {
+		summary := summarizeIPs(byNamespace[namespace])
+		counts := []string{pluralize(summary.pods, "pod")}
+		if summary.services > 0 {
+			counts = append(counts, pluralize(summary.services, "service"))
+		}
+		if summary.endpointSlices > 0 {
+			counts = append(counts, pluralize(summary.endpointSlices, "endpoint slice"))
+		}
+		counts = append(counts, pluralize(summary.ips, "IP"))
+		lines = append(lines, fmt.Sprintf("%s: %s", namespace, strings.Join(counts, ", ")))
+	}
+
+	return lines
+}
+
+func pluralize(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, noun)
+	}
+
+	return fmt.Sprintf("%d %ss", count, noun)
+}
+
+// listFromTable rebuilds the list of objects attached to the table rows, for printers working on raw objects.
+func listFromTable(table *metav1.Table) runtime.Object {
+	if len(table.Rows) > 0 {
+		if _, ok := table.Rows[0].Object.Object.(*corev1.Node); ok {
+			return nodeListFromTable(table)
+		}
+	}
+
+	return podListFromTable(table)
+}
+
+// podListFromTable collects the distinct pods behind the table rows, preserving row order.
+func podListFromTable(table *metav1.Table) *corev1.PodList {
+	podList := &corev1.PodList{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "List"},
+	}
+	seen := make(map[*corev1.Pod]bool)
+
+	for _, row := range table.Rows {
+		pod, ok := row.Object.Object.(*corev1.Pod)
+		if !ok || seen[pod] {
+			continue
+		}
+		seen[pod] = true
+
+		item := pod.DeepCopy()
+		item.APIVersion = "v1"
+		item.Kind = "Pod"
+		podList.Items = append(podList.Items, *item)
+	}
+
+	return podList
+}
+
+func extractPodIPsWithPods(pods *corev1.PodList, filter ipFilter) []podIPWithPod {
 	var podIPs []podIPWithPod
 	uniqueIPs := make(map[string]bool)
 
 	for i := range pods.Items {
 		pod := &pods.Items[i]
-		if pod.Status.PodIP != "" {
-			podIPs = append(podIPs, podIPWithPod{
-				pod: pod,
-				ip:  pod.Status.PodIP,
-			})
-			uniqueIPs[pod.Status.PodIP] = true
+		if !filter.includesNamespace(pod.Namespace) || !filter.includesStatus(pod) || !filter.includesName(pod) ||
+			!filter.includesNetworking(pod) || !filter.includesAge(pod) {
+			continue
+		}
+		if filter.keepDuplicates {
+			// every pod reports its addresses, deduplicating only the PodIP repeated in PodIPs
+			clear(uniqueIPs)
 		}
 
-		for _, ip := range pod.Status.PodIPs {
-			if ip.IP != "" && !uniqueIPs[ip.IP] {
+		for _, address := range podAddresses(pod, filter.source) {
+			ip := canonicalIP(address.ip)
+			if filter.skipInvalid && net.ParseIP(ip) == nil {
+				continue
+			}
+			if ip != "" && !uniqueIPs[ip] && filter.matches(ip) {
 				podIPs = append(podIPs, podIPWithPod{
-					pod: pod,
-					ip:  ip.IP,
+					pod:   pod,
+					ip:    ip,
+					iface: address.iface,
 				})
-				uniqueIPs[ip.IP] = true
+				uniqueIPs[ip] = true
 			}
 		}
+
+		// a pod without an address cannot match an address or range, so it is only listed without those filters
+		if filter.includePending && !hasPodIP(pod, filter.source) && filter.cidr == nil && filter.address == nil {
+			podIPs = append(podIPs, podIPWithPod{pod: pod})
+		}
 	}
 
 	return podIPs
 }
 
-func sortPodIPsWithPods(podIPs []podIPWithPod) {
-	sort.Slice(podIPs, func(i, j int) bool {
-		if podIPs[i].pod.Namespace != podIPs[j].pod.Namespace {
-			return podIPs[i].pod.Namespace < podIPs[j].pod.Namespace
+// podAddress is an IP address of a pod with the interface reporting it, empty when read from the status.
+type podAddress struct {
+	ip    string
+	iface string
+}
+
+// podAddresses returns the IP addresses of the pod from the source, in the order the pod reports them. The
+// annotation source falls back to the status when the pod has no readable network-status annotation.
+func podAddresses(pod *corev1.Pod, source string) []podAddress {
+	if source == ipSourceAnnotation {
+		if addresses, ok := networkStatusAddresses(pod); ok {
+			return addresses
 		}
-		if podIPs[i].pod.Name != podIPs[j].pod.Name {
-			return podIPs[i].pod.Name < podIPs[j].pod.Name
+	}
+
+	addresses := []podAddress{{ip: pod.Status.PodIP}}
+	for _, ip := range pod.Status.PodIPs {
+		addresses = append(addresses, podAddress{ip: ip.IP})
+	}
+
+	return addresses
+}
+
+// networkStatusAddresses returns the addresses of every interface listed in the Multus network-status
+// annotation, or in its deprecated networks-status spelling, reporting whether the pod has a well-formed one.
+func networkStatusAddresses(pod *corev1.Pod) ([]podAddress, bool) {
+	value, ok := pod.Annotations[networkStatusAnnotation]
+	if !ok {
+		value, ok = pod.Annotations[deprecatedNetworkStatusAnnotation]
+	}
+	if !ok {
+		return nil, false
+	}
+
+	var networks []struct {
+		Interface string   `json:"interface"`
+		IPs       []string `json:"ips"`
+	}
+	if err := json.Unmarshal([]byte(value), &networks); err != nil {
+		return nil, false
+	}
+
+	var addresses []podAddress
+	for _, network := range networks {
+		for _, ip := range network.IPs {
+			addresses = append(addresses, podAddress{ip: ip, iface: network.Interface})
+		}
+	}
+
+	return addresses, true
+}
+
+// malformedPodIPs returns the addresses of the pods that do not parse as IP addresses, which only a faulty CNI
+// plugin reports.
+func malformedPodIPs(pods *corev1.PodList, source string) []podIPWithPod {
+	var malformed []podIPWithPod
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		// the PodIP is repeated in PodIPs
+		seen := make(map[string]bool)
+		for _, address := range podAddresses(pod, source) {
+			if address.ip != "" && !seen[address.ip] && net.ParseIP(address.ip) == nil {
+				malformed = append(malformed, podIPWithPod{pod: pod, ip: address.ip})
+			}
+			seen[address.ip] = true
+		}
+	}
+
+	return malformed
+}
+
+// canonicalIP returns the address in the canonical form of net.IP, e.g. compressed lower-case IPv6, so that
+// differently written copies of an address compare equal. Unparsable values are kept as they are.
+func canonicalIP(ip string) string {
+	if parsed := net.ParseIP(ip); parsed != nil {
+		return parsed.String()
+	}
+
+	return ip
+}
+
+// hasPodIP reports whether the pod has been assigned any IP address in the source.
+func hasPodIP(pod *corev1.Pod, source string) bool {
+	return slices.ContainsFunc(podAddresses(pod, source), func(address podAddress) bool {
+		return address.ip != ""
+	})
+}
+
+// extractServiceIPs collects the cluster and external IP addresses of services, skipping headless ones.
+func extractServiceIPs(services *corev1.ServiceList, filter ipFilter) []podIPWithPod {
+	var serviceIPs []podIPWithPod
+	uniqueIPs := make(map[string]bool)
+
+	for i := range services.Items {
+		service := &services.Items[i]
+		if !filter.includesNamespace(service.Namespace) {
+			continue
+		}
+		if filter.keepDuplicates {
+			clear(uniqueIPs)
+		}
+
+		ips := []string{service.Spec.ClusterIP}
+		ips = append(ips, service.Spec.ClusterIPs...)
+		ips = append(ips, service.Spec.ExternalIPs...)
+
+		for _, ip := range ips {
+			ip = canonicalIP(ip)
+			if ip == "" || ip == corev1.ClusterIPNone || uniqueIPs[ip] || !filter.matches(ip) {
+				continue
+			}
+			serviceIPs = append(serviceIPs, podIPWithPod{
+				service: service,
+				ip:      ip,
+			})
+			uniqueIPs[ip] = true
+		}
+	}
+
+	return serviceIPs
+}
+
+// extractEndpointIPs collects the addresses of endpoint slices that are not backed by a pod, as those are listed
+// with the pod already. FQDN slices are skipped as they carry no IP addresses.
+func extractEndpointIPs(endpointSlices *discoveryv1.EndpointSliceList, filter ipFilter) []podIPWithPod {
+	var endpointIPs []podIPWithPod
+	uniqueIPs := make(map[string]bool)
+
+	for i := range endpointSlices.Items {
+		slice := &endpointSlices.Items[i]
+		if !filter.includesNamespace(slice.Namespace) || slice.AddressType == discoveryv1.AddressTypeFQDN {
+			continue
+		}
+		if filter.keepDuplicates {
+			clear(uniqueIPs)
+		}
+
+		for j := range slice.Endpoints {
+			endpoint := &slice.Endpoints[j]
+			if endpoint.TargetRef != nil && endpoint.TargetRef.Kind == podType {
+				continue
+			}
+
+			for _, ip := range endpoint.Addresses {
+				ip = canonicalIP(ip)
+				if ip == "" || uniqueIPs[ip] || !filter.matches(ip) {
+					continue
+				}
+				endpointIPs = append(endpointIPs, podIPWithPod{
+					endpointSlice: slice,
+					endpoint:      endpoint,
+					ip:            ip,
+				})
+				uniqueIPs[ip] = true
+			}
+		}
+	}
+
+	return endpointIPs
+}
+
+func sortPodIPsWithPods(podIPs []podIPWithPod, listOptions ipListOptions) {
+	sort.SliceStable(podIPs, func(i, j int) bool {
+		if result := comparePodIPsBy(listOptions, podIPs[i], podIPs[j]); result != 0 {
+			return result < 0
+		}
+		if podIPs[i].cluster != podIPs[j].cluster {
+			return podIPs[i].cluster < podIPs[j].cluster
+		}
+		metaI, metaJ := podIPs[i].meta(), podIPs[j].meta()
+		if metaI.Namespace != metaJ.Namespace {
+			return metaI.Namespace < metaJ.Namespace
+		}
+		if metaI.Name != metaJ.Name {
+			return metaI.Name < metaJ.Name
+		}
+		if result := compareIPs(podIPs[i].ip, podIPs[j].ip); result != 0 {
+			return result < 0
 		}
 
-		return podIPs[i].ip < podIPs[j].ip
+		// the UID settles ties such as an address reported by two endpoint slices, whatever the listing order
+		return metaI.UID < metaJ.UID
 	})
 }
+
+// comparePodIPsBy compares two entries by the requested sort key, returning 0 for the default order.
+func comparePodIPsBy(listOptions ipListOptions, a, b podIPWithPod) int {
+	switch listOptions.sortBy {
+	case sortByIP:
+		return compareIPs(a.ip, b.ip)
+	case sortByName:
+		return strings.Compare(a.meta().Name, b.meta().Name)
+	case sortByNamespace:
+		return strings.Compare(a.meta().Namespace, b.meta().Namespace)
+	case sortByAge:
+		return a.meta().CreationTimestamp.Compare(b.meta().CreationTimestamp.Time)
+	case sortByRestarts:
+		return cmp.Compare(a.restarts(listOptions.includeInitRestarts), b.restarts(listOptions.includeInitRestarts))
+	case sortByStatus:
+		return strings.Compare(a.phase(), b.phase())
+	default:
+		return 0
+	}
+}
+
+// compareIPs orders addresses numerically with IPv4 before IPv6, and unparsable values last.
+func compareIPs(a, b string) int {
+	addrA, errA := netip.ParseAddr(a)
+	addrB, errB := netip.ParseAddr(b)
+
+	switch {
+	case errA != nil && errB != nil:
+		return strings.Compare(a, b)
+	case errA != nil:
+		return 1
+	case errB != nil:
+		return -1
+	}
+
+	return addrA.Compare(addrB)
+}
@@ -0,0 +1,507 @@
+package cmd_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/andreygrechin/kubectl-ips/pkg/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newTestPod(name, podIP string, podIPs ...string) corev1.Pod {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Status: corev1.PodStatus{
+			PodIP: podIP,
+		},
+	}
+	for _, ip := range podIPs {
+		pod.Status.PodIPs = append(pod.Status.PodIPs, corev1.PodIP{IP: ip})
+	}
+
+	return pod
+}
+
+func TestExtractPodIPs(t *testing.T) {
+	pods := &corev1.PodList{
+		Items: []corev1.Pod{
+			newTestPod("dual-stack", "10.244.1.5", "10.244.1.5", "fd00::5"),
+			newTestPod("ipv4-only", "10.244.2.7"),
+			newTestPod("ipv6-only", "fd00::7", "fd00::7"),
+			newTestPod("pending", ""),
+		},
+	}
+
+	tests := map[string]struct {
+		cidr     string
+		family   string
+		address  string
+		expected []string
+	}{
+		"no filters": {
+			family:   "all",
+			expected: []string{"10.244.1.5", "fd00::5", "10.244.2.7", "fd00::7"},
+		},
+		"ipv4 cidr": {
+			cidr:     "10.244.1.0/24",
+			family:   "all",
+			expected: []string{"10.244.1.5"},
+		},
+		"ipv6 cidr": {
+			cidr:     "fd00::/64",
+			family:   "all",
+			expected: []string{"fd00::5", "fd00::7"},
+		},
+		"ipv4 family": {
+			family:   "ipv4",
+			expected: []string{"10.244.1.5", "10.244.2.7"},
+		},
+		"ipv6 family": {
+			family:   "ipv6",
+			expected: []string{"fd00::5", "fd00::7"},
+		},
+		"ipv4 family with ipv6 cidr": {
+			cidr:     "fd00::/64",
+			family:   "ipv4",
+			expected: []string{},
+		},
+		"lookup address": {
+			family:   "all",
+			address:  "10.244.2.7",
+			expected: []string{"10.244.2.7"},
+		},
+		"lookup non-canonical ipv6 address": {
+			family:   "all",
+			address:  "fd00:0:0:0:0:0:0:5",
+			expected: []string{"fd00::5"},
+		},
+		"lookup unknown address": {
+			family:   "all",
+			address:  "10.244.9.9",
+			expected: []string{},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var cidr *net.IPNet
+			if tc.cidr != "" {
+				var err error
+				_, cidr, err = net.ParseCIDR(tc.cidr)
+				require.NoError(t, err)
+			}
+
+			opts := cmd.ListOptions{CIDR: cidr, IPFamily: tc.family, Address: net.ParseIP(tc.address)}
+			result := cmd.ExtractPodIPs(pods, opts)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestExtractPodIPs_excludedNamespaces(t *testing.T) {
+	system := newTestPod("coredns", "10.244.0.2")
+	system.Namespace = "kube-system"
+	public := newTestPod("probe", "10.244.0.3")
+	public.Namespace = "kube-public"
+	pods := &corev1.PodList{
+		Items: []corev1.Pod{newTestPod("nginx", "10.244.1.5"), system, public},
+	}
+
+	tests := map[string]struct {
+		namespaces []string
+		expected   []string
+	}{
+		"nothing excluded": {
+			namespaces: nil,
+			expected:   []string{"10.244.1.5", "10.244.0.2", "10.244.0.3"},
+		},
+		"control-plane namespaces excluded": {
+			namespaces: []string{"kube-system", "kube-public"},
+			expected:   []string{"10.244.1.5"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, cmd.ExtractPodIPs(pods, cmd.ListOptions{ExcludeNamespaces: tc.namespaces}))
+		})
+	}
+}
+
+func TestExtractPodIPs_statuses(t *testing.T) {
+	running := newTestPod("running", "10.244.1.5")
+	running.Status.Phase = corev1.PodRunning
+	pending := newTestPod("pending", "10.244.1.6")
+	pending.Status.Phase = corev1.PodPending
+	terminating := newTestPod("terminating", "10.244.1.7")
+	terminating.Status.Phase = corev1.PodRunning
+	terminating.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	pods := &corev1.PodList{
+		Items: []corev1.Pod{running, pending, terminating},
+	}
+
+	tests := map[string]struct {
+		statuses []string
+		expected []string
+	}{
+		"no status filter": {
+			statuses: nil,
+			expected: []string{"10.244.1.5", "10.244.1.6", "10.244.1.7"},
+		},
+		"case-insensitive phases": {
+			statuses: []string{"running", "PENDING"},
+			expected: []string{"10.244.1.5", "10.244.1.6"},
+		},
+		"derived status": {
+			statuses: []string{"Terminating"},
+			expected: []string{"10.244.1.7"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, cmd.ExtractPodIPs(pods, cmd.ListOptions{Statuses: tc.statuses}))
+		})
+	}
+}
+
+func TestExtractPodIPs_hostNetwork(t *testing.T) {
+	hostNetwork := newTestPod("kube-proxy", "192.168.1.11")
+	hostNetwork.Spec.HostNetwork = true
+	pods := &corev1.PodList{
+		Items: []corev1.Pod{newTestPod("nginx", "10.244.1.5"), hostNetwork},
+	}
+
+	tests := map[string]struct {
+		excludeHostNetwork bool
+		hostNetworkOnly    bool
+		expected           []string
+	}{
+		"no host network filter": {
+			expected: []string{"10.244.1.5", "192.168.1.11"},
+		},
+		"exclude host network": {
+			excludeHostNetwork: true,
+			expected:           []string{"10.244.1.5"},
+		},
+		"host network only": {
+			hostNetworkOnly: true,
+			expected:        []string{"192.168.1.11"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			opts := cmd.ListOptions{ExcludeHostNetwork: tc.excludeHostNetwork, HostNetworkOnly: tc.hostNetworkOnly}
+			result := cmd.ExtractPodIPs(pods, opts)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestExtractPodIPs_duplicates(t *testing.T) {
+	proxy := newTestPod("kube-proxy", "192.168.1.11", "192.168.1.11")
+	proxy.Spec.HostNetwork = true
+	exporter := newTestPod("node-exporter", "192.168.1.11", "192.168.1.11")
+	exporter.Spec.HostNetwork = true
+	pods := &corev1.PodList{
+		Items: []corev1.Pod{proxy, exporter, newTestPod("nginx", "10.244.1.5")},
+	}
+
+	tests := map[string]struct {
+		keepDuplicates bool
+		expected       []string
+	}{
+		"deduplicated": {
+			keepDuplicates: false,
+			expected:       []string{"192.168.1.11", "10.244.1.5"},
+		},
+		"every pod": {
+			keepDuplicates: true,
+			expected:       []string{"192.168.1.11", "192.168.1.11", "10.244.1.5"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, cmd.ExtractPodIPs(pods, cmd.ListOptions{KeepDuplicates: tc.keepDuplicates}))
+		})
+	}
+}
+
+func TestExtractPodIPs_canonical(t *testing.T) {
+	pods := &corev1.PodList{
+		Items: []corev1.Pod{
+			newTestPod("expanded", "fd00:0:0:0:0:0:0:5", "fd00:0:0:0:0:0:0:5", "10.244.1.5"),
+			newTestPod("compressed", "fd00::5"),
+			newTestPod("malformed", "not-an-ip"),
+		},
+	}
+
+	assert.Equal(t, []string{"fd00::5", "10.244.1.5", "not-an-ip"}, cmd.ExtractPodIPs(pods, cmd.ListOptions{}))
+}
+
+func TestExtractPodIPs_ipv6Casing(t *testing.T) {
+	pods := &corev1.PodList{
+		Items: []corev1.Pod{
+			newTestPod("upper-case-pod-ip", "FD00::A", "fd00::a"),
+			newTestPod("upper-case-pod-ips", "fd00::b", "FD00:0:0::B", "10.244.1.6"),
+		},
+	}
+
+	result := cmd.ExtractPodIPs(pods, cmd.ListOptions{KeepDuplicates: true})
+	assert.Equal(t, []string{"fd00::a", "fd00::b", "10.244.1.6"}, result)
+}
+
+func TestExtractPodIPs_source(t *testing.T) {
+	multiNIC := newTestPod("multi-nic", "10.244.1.5", "10.244.1.5")
+	multiNIC.Annotations = map[string]string{"k8s.v1.cni.cncf.io/network-status": `[
+		{"name": "cbr0", "interface": "eth0", "ips": ["10.244.1.5"], "default": true},
+		{"name": "default/storage", "interface": "net1", "ips": ["192.168.100.5", "fd00:100::5"]}
+	]`}
+	malformed := newTestPod("malformed", "10.244.1.6")
+	malformed.Annotations = map[string]string{"k8s.v1.cni.cncf.io/network-status": "{"}
+	secondaryOnly := newTestPod("secondary-only", "")
+	secondaryOnly.Annotations = map[string]string{
+		"k8s.v1.cni.cncf.io/network-status": `[{"name": "default/storage", "ips": ["192.168.100.7"]}]`,
+	}
+	pods := &corev1.PodList{
+		Items: []corev1.Pod{multiNIC, malformed, newTestPod("plain", "10.244.1.7"), secondaryOnly},
+	}
+
+	tests := map[string]struct {
+		source   string
+		expected []string
+	}{
+		"status": {
+			source:   "status",
+			expected: []string{"10.244.1.5", "10.244.1.6", "10.244.1.7", ""},
+		},
+		"annotation": {
+			source:   "annotation",
+			expected: []string{"10.244.1.5", "192.168.100.5", "fd00:100::5", "10.244.1.6", "10.244.1.7", "192.168.100.7"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, cmd.ExtractPodIPs(pods, cmd.ListOptions{IPSource: tc.source, IncludePending: true}))
+		})
+	}
+}
+
+func TestExtractPodIPs_pending(t *testing.T) {
+	pods := &corev1.PodList{
+		Items: []corev1.Pod{newTestPod("nginx", "10.244.1.5"), newTestPod("starting", "")},
+	}
+	_, cidr, err := net.ParseCIDR("10.244.0.0/16")
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		cidr     *net.IPNet
+		expected []string
+	}{
+		"listed with empty ip": {
+			expected: []string{"10.244.1.5", ""},
+		},
+		"left out with cidr": {
+			cidr:     cidr,
+			expected: []string{"10.244.1.5"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, cmd.ExtractPodIPs(pods, cmd.ListOptions{CIDR: tc.cidr, IncludePending: true}))
+		})
+	}
+}
+
+func TestExtractServiceIPs(t *testing.T) {
+	services := &corev1.ServiceList{
+		Items: []corev1.Service{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+				Spec: corev1.ServiceSpec{
+					ClusterIP:   "10.96.0.10",
+					ClusterIPs:  []string{"10.96.0.10", "fd00:96::10"},
+					ExternalIPs: []string{"203.0.113.7", "FD00:96:0::10"},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "headless", Namespace: "default"},
+				Spec: corev1.ServiceSpec{
+					ClusterIP:  corev1.ClusterIPNone,
+					ClusterIPs: []string{corev1.ClusterIPNone},
+				},
+			},
+		},
+	}
+
+	expected := []string{"10.96.0.10", "fd00:96::10", "203.0.113.7"}
+	assert.Equal(t, expected, cmd.ExtractServiceIPs(services))
+}
+
+func TestSortPodIPs(t *testing.T) {
+	pods := &corev1.PodList{
+		Items: []corev1.Pod{
+			newTestPod("a", "10.244.10.2"),
+			newTestPod("b", "fd00::10", "fd00::10", "10.244.2.3"),
+			newTestPod("c", "fd00::9"),
+			newTestPod("d", "not-an-ip"),
+			newTestPod("e", "10.244.2.30"),
+		},
+	}
+
+	tests := map[string]struct {
+		sortBy   string
+		reverse  bool
+		expected []string
+	}{
+		"default order": {
+			sortBy:   "",
+			expected: []string{"10.244.10.2", "10.244.2.3", "fd00::10", "fd00::9", "not-an-ip", "10.244.2.30"},
+		},
+		"numeric ip order": {
+			sortBy:   "ip",
+			expected: []string{"10.244.2.3", "10.244.2.30", "10.244.10.2", "fd00::9", "fd00::10", "not-an-ip"},
+		},
+		"reversed default order": {
+			sortBy:   "",
+			reverse:  true,
+			expected: []string{"10.244.2.30", "not-an-ip", "fd00::9", "fd00::10", "10.244.2.3", "10.244.10.2"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, cmd.SortPodIPs(pods, tc.sortBy, tc.reverse))
+		})
+	}
+}
+
+func TestSortPodIPs_podKeys(t *testing.T) {
+	now := time.Now()
+	newPod := func(name, ip string, age time.Duration, restarts int32, phase corev1.PodPhase) corev1.Pod {
+		pod := newTestPod(name, ip)
+		pod.CreationTimestamp = metav1.NewTime(now.Add(-age))
+		pod.Status.Phase = phase
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{{RestartCount: restarts}}
+
+		return pod
+	}
+	pods := &corev1.PodList{
+		Items: []corev1.Pod{
+			newPod("a", "10.0.0.1", time.Hour, 3, corev1.PodRunning),
+			newPod("b", "10.0.0.2", 3*time.Hour, 0, corev1.PodPending),
+			newPod("c", "10.0.0.3", 2*time.Hour, 7, corev1.PodFailed),
+		},
+	}
+
+	tests := map[string]struct {
+		sortBy   string
+		reverse  bool
+		expected []string
+	}{
+		"by name": {
+			sortBy:   "name",
+			expected: []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"},
+		},
+		"by age": {
+			sortBy:   "age",
+			expected: []string{"10.0.0.2", "10.0.0.3", "10.0.0.1"},
+		},
+		"by age reversed": {
+			sortBy:   "age",
+			reverse:  true,
+			expected: []string{"10.0.0.1", "10.0.0.3", "10.0.0.2"},
+		},
+		"by restarts": {
+			sortBy:   "restarts",
+			expected: []string{"10.0.0.2", "10.0.0.1", "10.0.0.3"},
+		},
+		"by status": {
+			sortBy:   "status",
+			expected: []string{"10.0.0.3", "10.0.0.2", "10.0.0.1"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, cmd.SortPodIPs(pods, tc.sortBy, tc.reverse))
+		})
+	}
+}
+
+func TestSortPodIPs_uidTiebreaker(t *testing.T) {
+	newPod := func(uid string) corev1.Pod {
+		pod := newTestPod("host-agent", "192.168.1.11")
+		pod.UID = types.UID(uid)
+
+		return pod
+	}
+
+	listed := &corev1.PodList{Items: []corev1.Pod{newPod("c"), newPod("a"), newPod("b")}}
+	relisted := &corev1.PodList{Items: []corev1.Pod{newPod("b"), newPod("c"), newPod("a")}}
+
+	assert.Equal(t, []string{"a", "b", "c"}, cmd.SortPodUIDs(listed))
+	assert.Equal(t, []string{"a", "b", "c"}, cmd.SortPodUIDs(relisted))
+}
+
+func TestSummarizeIPs(t *testing.T) {
+	system := newTestPod("coredns", "10.244.0.2")
+	system.Namespace = "kube-system"
+	pods := &corev1.PodList{
+		Items: []corev1.Pod{
+			newTestPod("dual-stack", "10.244.1.5", "10.244.1.5", "fd00::5"),
+			newTestPod("pending", ""),
+			system,
+		},
+	}
+	services := &corev1.ServiceList{
+		Items: []corev1.Service{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+				Spec:       corev1.ServiceSpec{ClusterIP: "10.96.0.10"},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		pods     *corev1.PodList
+		services *corev1.ServiceList
+		expected string
+	}{
+		"pods only": {
+			pods:     pods,
+			expected: "Total: 3 IPs across 2 pods in 2 namespaces",
+		},
+		"pods and services": {
+			pods:     pods,
+			services: services,
+			expected: "Total: 4 IPs across 2 pods and 1 service in 2 namespaces",
+		},
+		"single pod": {
+			pods:     &corev1.PodList{Items: []corev1.Pod{newTestPod("nginx", "10.244.1.5")}},
+			expected: "Total: 1 IP across 1 pod in 1 namespace",
+		},
+		"no pods": {
+			pods:     &corev1.PodList{},
+			expected: "Total: 0 IPs across 0 pods in 0 namespaces",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, cmd.SummarizeIPs(tc.pods, tc.services))
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// defaultWaitInterval is how long --wait pauses between listings.
+	defaultWaitInterval = 2 * time.Second
+	// defaultWaitTimeout gives a rollout a few minutes to get its pods scheduled and networked.
+	defaultWaitTimeout = 5 * time.Minute
+)
+
+// waitForPodIPs lists the pod IPs every waitInterval until at least --wait-count pods have one, failing with
+// ErrWaitTimeout when --wait-timeout elapses first. A zero timeout waits until the context is canceled.
+func (o *IPsOptions) waitForPodIPs(ctx context.Context, clientset kubernetes.Interface) ([]podIPWithPod, error) {
+	var deadline <-chan time.Time
+	if o.waitTimeout > 0 {
+		timer := time.NewTimer(o.waitTimeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
+	for {
+		podIPs, err := o.collectIPs(ctx, clientset)
+		if err != nil {
+			return nil, err
+		}
+
+		ready := summarizeIPs(podIPs).pods
+		if ready >= o.waitCount {
+			return podIPs, nil
+		}
+		o.logf("Waiting for %s with an IP, %d so far", pluralize(o.waitCount, "pod"), ready)
+
+		interval := time.NewTimer(o.waitInterval)
+		select {
+		case <-ctx.Done():
+			interval.Stop()
+
+			return nil, fmt.Errorf("stopped waiting for pod IPs: %w", ctx.Err())
+		case <-deadline:
+			interval.Stop()
+
+			return nil, fmt.Errorf("%w: %d of %s had an IP after %s",
+				ErrWaitTimeout, ready, pluralize(o.waitCount, "pod"), formatDuration(o.waitTimeout))
+		case <-interval.C:
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/client-go/kubernetes"
+)
+
+// deletedStatus replaces the status of the rows printed for a deleted pod.
+const deletedStatus = "Deleted"
+
+// runWatch prints the current pod IPs and then streams a row for every added, modified or deleted pod
+// until the watch is closed, failing when the context is canceled first. The rows of a deleted pod show
+// deletedStatus as their status.
+func (o *IPsOptions) runWatch(ctx context.Context, clientset kubernetes.Interface) error {
+	var listOptions metav1.ListOptions
+	var pods *corev1.PodList
+	err := o.withTimeout(ctx, func(ctx context.Context) error {
+		var err error
+		listOptions, err = o.podListOptionsIn(ctx, clientset, o.namespace)
+		if err != nil {
+			return err
+		}
+		pods, err = listPods(ctx, clientset, o.namespace, listOptions, o.chunkSize)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	pods.Items = slices.DeleteFunc(pods.Items, func(pod corev1.Pod) bool {
+		return !o.matchesPodNames(&pod)
+	})
+
+	// table output shares one tab writer so that streamed rows stay aligned with the initial ones
+	out := o.Out
+	var flush func() error
+	if o.outputFormat == tableFormat || o.outputFormat == wideFormat || o.outputFormat == wideIPv6Format ||
+		o.outputFormat == "" {
+		writer := printers.GetNewTabWriter(o.Out)
+		out, flush = writer, writer.Flush
+	}
+
+	printer, err := createPrinter(o.outputFormat, o.printOptions())
+	if err != nil {
+		return err
+	}
+	if err := o.printWatchedPods(pods, false, printer, out, flush); err != nil {
+		return err
+	}
+
+	// headers were printed with the initial state, streamed rows go without them
+	rowOptions := o.printOptions()
+	rowOptions.noHeaders = true
+	rowPrinter, err := createPrinter(o.outputFormat, rowOptions)
+	if err != nil {
+		return err
+	}
+
+	listOptions.ResourceVersion = pods.ResourceVersion
+	watcher, err := clientset.CoreV1().Pods(o.namespace).Watch(ctx, listOptions)
+	if err != nil {
+		return fmt.Errorf("failed to watch pods: %w", classifyAPIError(err))
+	}
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("watch interrupted: %w", ctx.Err())
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return nil
+			}
+			if event.Type == watch.Error {
+				return fmt.Errorf("failed to watch pods: %w", classifyAPIError(apierrors.FromObject(event.Object)))
+			}
+
+			pod, ok := event.Object.(*corev1.Pod)
+			if !ok || !o.matchesPodNames(pod) {
+				continue
+			}
+
+			changed := &corev1.PodList{Items: []corev1.Pod{*pod}}
+			if err := o.printWatchedPods(changed, event.Type == watch.Deleted, rowPrinter, out, flush); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (o *IPsOptions) printWatchedPods(
+	pods *corev1.PodList, deleted bool, printer ResourcePrinter, out io.Writer, flush func() error,
+) error {
+	o.dropNotRunningPods(pods)
+	listOptions := o.ipListOptions()
+	if deleted {
+		// the pod is shown even when its IP was released before the deletion
+		listOptions.filter.includePending = true
+	}
+
+	if o.showIPsOnly {
+		if deleted {
+			// the addresses of a deleted pod are no longer in use
+			return nil
+		}
+		printer = &ipOnlyPrinter{listOptions: listOptions, expandIPv6: o.expandIPv6, delimiter: o.ipsSeparator}
+		if err := printer.PrintObj(pods, out); err != nil {
+			return fmt.Errorf("failed to print object: %w", err)
+		}
+	} else {
+		table := generateTable(ipSources{pods: pods}, listOptions, o.columnOptions())
+		if len(table.Rows) == 0 {
+			return nil
+		}
+		if deleted {
+			markDeleted(table, o.outputFormat == nameFormat)
+		}
+		if err := printer.PrintObj(table, out); err != nil {
+			return fmt.Errorf("failed to print object: %w", err)
+		}
+	}
+
+	if flush != nil {
+		if err := flush(); err != nil {
+			return fmt.Errorf("failed to flush output: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// markDeleted shows deletedStatus in the STATUS column of the rows of a deleted pod. Name output has no other
+// column, so the name is followed by "deleted" instead, as kubectl delete prints it.
+func markDeleted(table *metav1.Table, nameOnly bool) {
+	column := columnIndex(table, "STATUS")
+	if nameOnly {
+		column = max(columnIndex(table, "NAME"), 0)
+	}
+	if column < 0 {
+		return
+	}
+
+	for i := range table.Rows {
+		cells := table.Rows[i].Cells
+		if column >= len(cells) {
+			continue
+		}
+		if nameOnly {
+			cells[column] = fmt.Sprintf("%v deleted", cells[column])
+		} else {
+			cells[column] = deletedStatus
+		}
+	}
+}
+
+// validateWatch rejects the flags whose output cannot be updated row by row as pods change.
+func (o *IPsOptions) validateWatch() error {
+	if !o.watch {
+		return nil
+	}
+
+	switch {
+	case o.includeServices:
+		return fmt.Errorf("%w: --include-services cannot be used with --watch", ErrIncompatibleFlags)
+	case o.count:
+		return fmt.Errorf("%w: --count cannot be used with --watch", ErrIncompatibleFlags)
+	case o.maxPods > 0:
+		return fmt.Errorf("%w: --max-pods cannot be used with --watch", ErrIncompatibleFlags)
+	case len(o.nodeLabelColumns) > 0:
+		return fmt.Errorf("%w: --show-node-labels cannot be used with --watch", ErrIncompatibleFlags)
+	}
+
+	return nil
+}
+
+// matchesPodNames reports whether the pod was requested by name, accepting every pod when no names were given.
+func (o *IPsOptions) matchesPodNames(pod *corev1.Pod) bool {
+	return len(o.podNames) == 0 || slices.Contains(o.podNames, pod.Name)
+}